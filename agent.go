@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 
+	"github.com/containerd/containerd"
 	"github.com/crosbymichael/boss/agent"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/config"
+	"github.com/crosbymichael/boss/ingress"
+	"github.com/crosbymichael/boss/resolver"
 	"github.com/crosbymichael/boss/system"
 	"github.com/crosbymichael/boss/util"
 	"github.com/ehazlett/element"
@@ -71,8 +75,8 @@ var agentCommand = cli.Command{
 		}
 		var (
 			labels         = make(map[string]string)
-			address        = fmt.Sprintf("%s:%d", ip, clix.Int("agent-port"))
-			clusterAddress = fmt.Sprintf("%s:%d", ip, clix.Int("cluster-port"))
+			address        = net.JoinHostPort(ip, strconv.Itoa(clix.Int("agent-port")))
+			clusterAddress = net.JoinHostPort(ip, strconv.Itoa(clix.Int("cluster-port")))
 			peers          = append(c.Agent.Peers, clix.StringSlice("peers")...)
 		)
 		if c.Agent.Master {
@@ -111,6 +115,19 @@ var agentCommand = cli.Command{
 		if err != nil {
 			return err
 		}
+		go a.SyncPolicies(system.Context())
+		go a.PublishRecords(system.Context())
+		go a.SyncRegistrations(system.Context())
+		if c.Resolver != nil {
+			if err := serveDNS(c, a); err != nil {
+				return err
+			}
+		}
+		if c.Ingress != nil {
+			if err := serveIngress(system.Context(), client, c.Ingress); err != nil {
+				return err
+			}
+		}
 		server := newServer()
 		v1.RegisterAgentServer(server, a)
 		go func() {
@@ -128,6 +145,49 @@ var agentCommand = cli.Command{
 	},
 }
 
+// serveIngress routes http requests on the node to the ingress of container services
+func serveIngress(ctx context.Context, client *containerd.Client, c *config.Ingress) error {
+	proxy := ingress.New(client)
+	go proxy.Run(ctx)
+	l, err := net.Listen("tcp", c.HTTPAddress())
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(l, proxy); err != nil {
+			logrus.WithError(err).Error("serve ingress")
+		}
+	}()
+	if c.Cert == "" || c.Key == "" {
+		return nil
+	}
+	tl, err := net.Listen("tcp", c.HTTPSAddress())
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.ServeTLS(tl, proxy, c.Cert, c.Key); err != nil {
+			logrus.WithError(err).Error("serve tls ingress")
+		}
+	}()
+	return nil
+}
+
+// serveDNS answers for the services and containers of the cluster from the agent's records
+func serveDNS(c *config.Config, a *agent.Agent) error {
+	upstreams, err := c.Resolver.UpstreamAddresses()
+	if err != nil {
+		return err
+	}
+	server := resolver.New(c.Domain, upstreams, a.Records)
+	go func() {
+		if err := server.Serve(c.Resolver.ListenAddress()); err != nil {
+			logrus.WithError(err).Error("serve dns")
+		}
+	}()
+	return nil
+}
+
 func newServer() *grpc.Server {
 	s := grpc.NewServer(
 		grpc.UnaryInterceptor(unary),
@@ -6,14 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/containerd/cgroups"
 	"github.com/containerd/containerd"
@@ -31,11 +32,15 @@ import (
 	"github.com/containerd/typeurl"
 	"github.com/crosbymichael/boss/api"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/cni"
 	"github.com/crosbymichael/boss/config"
 	"github.com/crosbymichael/boss/flux"
 	"github.com/crosbymichael/boss/opts"
+	bstore "github.com/crosbymichael/boss/store"
+	"github.com/crosbymichael/boss/system"
 	"github.com/crosbymichael/boss/systemd"
 	"github.com/ehazlett/element"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/gomodule/redigo/redis"
 	ver "github.com/opencontainers/image-spec/specs-go"
@@ -95,6 +100,9 @@ func New(c *config.Config, client *containerd.Client, store config.ConfigStore,
 			return nil, errors.New("unable to find master in cluster")
 		}
 	}
+	if err := writeStoreAddress(master); err != nil {
+		return nil, err
+	}
 	mp := newPool(master)
 	var lp *redis.Pool
 	if c.Agent.Master {
@@ -111,6 +119,9 @@ func New(c *config.Config, client *containerd.Client, store config.ConfigStore,
 			return nil, err
 		}
 	}
+	// reservations are made on the master so they are conflict free across nodes
+	ipam := bstore.NewIPAM(mp, c.ID)
+	c.SetIPAM(ipam)
 	agent := &Agent{
 		c:        c,
 		client:   client,
@@ -120,13 +131,26 @@ func New(c *config.Config, client *containerd.Client, store config.ConfigStore,
 		server:   server,
 		master:   mp,
 		local:    lp,
+		ipam:     ipam,
 	}
 	if err := agent.handleResolvConf(); err != nil {
 		return nil, err
 	}
+	if err := agent.reconcileRoutes(); err != nil {
+		logrus.WithError(err).Error("reconcile container routes")
+	}
 	return agent, nil
 }
 
+// writeStoreAddress publishes the master store address for the container processes
+func writeStoreAddress(address string) error {
+	path := v1.StoreAddressPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0711); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(address), 0644)
+}
+
 func newPool(address string) *redis.Pool {
 	return redis.NewPool(func() (redis.Conn, error) {
 		return redis.Dial("tcp", address)
@@ -166,6 +190,9 @@ type Agent struct {
 	server   *server.App
 	master   *redis.Pool
 	local    *redis.Pool
+	ipam     v1.IPAM
+	policies appliedPolicies
+	records  recordsCache
 }
 
 func (a *Agent) Close() error {
@@ -190,25 +217,48 @@ func (a *Agent) Create(ctx context.Context, req *v1.CreateRequest) (*types.Empty
 		})
 		return empty, err
 	}
+	if _, err := system.StopSignal(req.Container); err != nil {
+		return nil, err
+	}
+	if err := systemd.ValidateRestartPolicy(req.Container.Restart); err != nil {
+		return nil, err
+	}
+	if err := a.pullImages(ctx, req.Container); err != nil {
+		return nil, err
+	}
 	volumeRoot, err := redis.String(a.doLocal("GET", v1.VolumeRootKey))
 	if err != nil && err != redis.ErrNil {
 		return nil, err
 	}
+	ips, err := a.reserveIPs(req.Container, "")
+	if err != nil {
+		return nil, err
+	}
 	container, err := a.client.NewContainer(ctx,
 		req.Container.ID,
-		flux.WithNewSnapshot(image),
+		withNewSnapshot(req.Container, image),
 		opts.WithBossConfig(volumeRoot, req.Container, image),
+		opts.WithReservedIPs(ips),
 	)
 	if err != nil {
+		a.releaseIPs(req.Container)
 		return nil, err
 	}
 	if err := a.store.Write(ctx, req.Container); err != nil {
-		container.Delete(ctx, containerd.WithSnapshotCleanup)
+		container.Delete(ctx, flux.WithRevisionCleanup)
+		a.releaseIPs(req.Container)
 		return nil, err
 	}
-	if err := systemd.Enable(ctx, container.ID()); err != nil {
+	if err := setupUnit(ctx, req.Container); err != nil {
+		systemd.RemoveDropIns(container.ID())
+		container.Delete(ctx, flux.WithRevisionCleanup)
+		a.releaseIPs(req.Container)
 		return nil, err
 	}
+	// jobs are only run on their schedule or on demand
+	if req.Container.Type == v1.JobType {
+		return empty, nil
+	}
 	if err := systemd.Start(ctx, container.ID()); err != nil {
 		return nil, err
 	}
@@ -231,11 +281,17 @@ func (a *Agent) Delete(ctx context.Context, req *v1.DeleteRequest) (*types.Empty
 	if err := systemd.Disable(ctx, id); err != nil {
 		return nil, errors.Wrap(err, "disable service")
 	}
+	if err := systemd.RemoveTimer(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "remove timer")
+	}
+	if err := systemd.RemoveDropIns(id); err != nil {
+		return nil, errors.Wrap(err, "remove service drop-ins")
+	}
 	config, err := opts.GetConfig(ctx, container)
 	if err != nil {
 		return nil, errors.Wrap(err, "load config")
 	}
-	network, err := a.c.GetNetwork(config.Network)
+	network, err := a.c.ContainerNetwork(config)
 	if err != nil {
 		return nil, errors.Wrap(err, "get network")
 	}
@@ -308,16 +364,24 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 	if err != nil {
 		return nil, err
 	}
+	sidecars, err := a.sidecars(ctx, c.ID(), cfg)
+	if err != nil {
+		return nil, err
+	}
 	task, err := c.Task(ctx, nil)
 	if err != nil {
 		if errdefs.IsNotFound(err) {
 			return &v1.ContainerInfo{
-				ID:        c.ID(),
-				Image:     info.Image,
-				Status:    string(containerd.Stopped),
-				FsSize:    usage.Size + bindSizes,
-				Config:    cfg,
-				Snapshots: ss,
+				ID:           c.ID(),
+				Image:        info.Image,
+				Status:       string(containerd.Stopped),
+				FsSize:       usage.Size + bindSizes,
+				Config:       cfg,
+				Snapshots:    ss,
+				Restarts:     opts.LabelUint(info.Labels, opts.RestartCountLabel),
+				Failures:     opts.LabelUint(info.Labels, opts.FailureCountLabel),
+				LastExitCode: lastExitCode(info.Labels),
+				Sidecars:     sidecars,
 			}, nil
 		}
 		return nil, err
@@ -341,25 +405,62 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 		limit  = float64(cg.Memory.Usage.Limit)
 	)
 	return &v1.ContainerInfo{
-		ID:          c.ID(),
-		Image:       info.Image,
-		Status:      string(status.Status),
-		IP:          info.Labels[opts.IPLabel],
-		Cpu:         cpu,
-		MemoryUsage: memory,
-		MemoryLimit: limit,
-		PidUsage:    cg.Pids.Current,
-		PidLimit:    cg.Pids.Limit,
-		FsSize:      usage.Size + bindSizes,
-		Config:      cfg,
-		Snapshots:   ss,
+		ID:           c.ID(),
+		Image:        info.Image,
+		Status:       string(status.Status),
+		IP:           info.Labels[opts.IPLabel],
+		IPv6:         info.Labels[opts.IPv6Label],
+		Networks:     opts.NetworkIPs(info.Labels, cfg),
+		Cpu:          cpu,
+		MemoryUsage:  memory,
+		MemoryLimit:  limit,
+		PidUsage:     cg.Pids.Current,
+		PidLimit:     cg.Pids.Limit,
+		FsSize:       usage.Size + bindSizes,
+		Config:       cfg,
+		Snapshots:    ss,
+		Restarts:     opts.LabelUint(info.Labels, opts.RestartCountLabel),
+		Failures:     opts.LabelUint(info.Labels, opts.FailureCountLabel),
+		LastExitCode: lastExitCode(info.Labels),
+		Sidecars:     sidecars,
 	}, nil
 }
 
+func (a *Agent) sidecars(ctx context.Context, id string, config *v1.Container) ([]*v1.SidecarInfo, error) {
+	var o []*v1.SidecarInfo
+	for _, sc := range config.Sidecars {
+		status := string(containerd.Stopped)
+		c, err := a.client.LoadContainer(ctx, opts.SidecarID(id, sc.Name))
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			if status, err = taskStatus(ctx, c); err != nil {
+				return nil, err
+			}
+		}
+		o = append(o, &v1.SidecarInfo{
+			Name:   sc.Name,
+			Image:  sc.Image,
+			Status: status,
+		})
+	}
+	return o, nil
+}
+
+func lastExitCode(labels map[string]string) int32 {
+	code, err := strconv.Atoi(labels[opts.ExitCodeLabel])
+	if err != nil {
+		return 0
+	}
+	return int32(code)
+}
+
 func (a *Agent) List(ctx context.Context, req *v1.ListRequest) (*v1.ListResponse, error) {
 	var resp v1.ListResponse
 	ctx = relayContext(ctx)
-	containers, err := a.client.Containers(ctx)
+	containers, err := a.containers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -378,6 +479,29 @@ func (a *Agent) List(ctx context.Context, req *v1.ListRequest) (*v1.ListResponse
 	return &resp, nil
 }
 
+// containers returns the containers managed by boss without any init containers or sidecars
+func (a *Agent) containers(ctx context.Context) ([]containerd.Container, error) {
+	containers, err := a.client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var o []containerd.Container
+	for _, c := range containers {
+		labels, err := c.Labels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := labels[opts.InitLabel]; ok {
+			continue
+		}
+		if _, ok := labels[opts.SidecarLabel]; ok {
+			continue
+		}
+		o = append(o, c)
+	}
+	return o, nil
+}
+
 func (a *Agent) Kill(ctx context.Context, req *v1.KillRequest) (*types.Empty, error) {
 	ctx = relayContext(ctx)
 	id := req.ID
@@ -401,10 +525,10 @@ func (a *Agent) Kill(ctx context.Context, req *v1.KillRequest) (*types.Empty, er
 	if err != nil {
 		return nil, err
 	}
-	if err := task.Kill(ctx, unix.SIGTERM); err != nil {
-		return nil, err
+	if req.Signal != 0 {
+		return empty, task.Kill(ctx, unix.Signal(req.Signal))
 	}
-	return empty, nil
+	return empty, system.Stop(ctx, container, task, config)
 }
 
 func (a *Agent) Start(ctx context.Context, req *v1.StartRequest) (*types.Empty, error) {
@@ -413,6 +537,14 @@ func (a *Agent) Start(ctx context.Context, req *v1.StartRequest) (*types.Empty,
 	if id == "" {
 		return nil, ErrNoID
 	}
+	container, err := a.client.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// a manual start gives a container that gave up restarting another chance
+	if err := container.Update(ctx, opts.WithoutFailures); err != nil {
+		return nil, err
+	}
 	return empty, systemd.Start(ctx, req.ID)
 }
 
@@ -440,57 +572,83 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 	if err != nil {
 		return nil, err
 	}
-	// set all current services into maintaince mode
-	for name := range current.Services {
-		if err := a.register.EnableMaintainance(container.ID(), name, "update container configuration"); err != nil {
-			logrus.WithError(err).Errorf("enable maintaince %s-%s", container.ID(), name)
-		}
+	if _, err := system.StopSignal(req.Container); err != nil {
+		return nil, err
+	}
+	if err := systemd.ValidateRestartPolicy(req.Container.Restart); err != nil {
+		return nil, err
+	}
+	if err := a.pullImages(ctx, req.Container); err != nil {
+		return nil, err
 	}
 	volumeRoot, err := redis.String(a.doLocal("GET", v1.VolumeRootKey))
 	if err != nil && err != redis.ErrNil {
 		return nil, err
 	}
-	var changes []change
-	for name := range current.Services {
-		if _, ok := req.Container.Services[name]; !ok {
-			// if the new config does not have a service, deregister the old one
-			changes = append(changes, &deregisterChange{
-				register: a.register,
-				name:     name,
-			})
-		}
-	}
-	changes = append(changes, &imageUpdateChange{
-		ref:    req.Container.Image,
-		client: a.client,
-		a:      a,
-	})
-	changes = append(changes, &configChange{
-		client:     a.client,
-		c:          req.Container,
-		volumeRoot: volumeRoot,
-	})
-	changes = append(changes, &filesChange{
-		c:     req.Container,
-		store: a.store,
-	})
-
-	var wait <-chan containerd.ExitStatus
-	// bump the task to pickup the changes
 	task, err := container.Task(ctx, nil)
 	if err != nil {
 		if !errdefs.IsNotFound(err) {
 			return nil, err
 		}
 	}
-	if task != nil {
-		if wait, err = task.Wait(ctx); err != nil {
+	changes, err := a.changes(ctx, container, task, current, req.Container, volumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.UpdateResponse{
+		Container: req.Container,
+		Action:    v1.UpdateActionRestart,
+	}
+	kind := maxKind(changes)
+	switch {
+	case len(changes) == 0:
+		resp.Action = v1.UpdateActionNone
+	case kind == kindRevision:
+		resp.Action = v1.UpdateActionRevision
+	}
+	// running jobs are never interrupted, changes are picked up by the next run
+	if task == nil || kind < kindRestart || current.Type == v1.JobType {
+		apply := func() error {
+			for _, ch := range changes {
+				if err := ch.update(ctx, container); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		// a running job's revision is swapped while it is paused like a service's
+		running, err := isRunning(ctx, container)
+		if err != nil {
 			return nil, err
 		}
-	} else {
-		c := make(chan containerd.ExitStatus)
-		wait = c
-		close(c)
+		if running && kind >= kindRestart {
+			err = pauseAndRun(ctx, container, apply)
+		} else {
+			err = apply()
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case task == nil, current.Type == v1.JobType, len(changes) == 0:
+		case kind == kindReload:
+			resp.Action = v1.UpdateActionReload
+		default:
+			resp.Action = v1.UpdateActionLive
+		}
+		return resp, nil
+	}
+	// set all current services into maintaince mode
+	for name := range current.Services {
+		if err := a.register.EnableMaintainance(container.ID(), name, "update container configuration"); err != nil {
+			logrus.WithError(err).Errorf("enable maintaince %s-%s", container.ID(), name)
+		}
+	}
+	// bump the task to pickup the changes
+	system.PreStop(ctx, container, task, current)
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return nil, err
 	}
 	err = pauseAndRun(ctx, container, func() error {
 		for _, ch := range changes {
@@ -498,27 +656,100 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 				return err
 			}
 		}
-		if task == nil {
-			return nil
-		}
-		return task.Kill(ctx, unix.SIGTERM)
+		return system.Signal(ctx, task, current)
 	})
 	if err != nil {
 		return nil, err
 	}
-	wctx, _ := context.WithTimeout(ctx, 10*time.Second)
-	for {
-		select {
-		case <-wctx.Done():
-			if task != nil {
-				return &v1.UpdateResponse{}, task.Kill(ctx, unix.SIGKILL)
+	return resp, system.WaitOrKill(ctx, task, wait, current)
+}
+
+// changes returns what is needed to move the container from the current config to the next
+func (a *Agent) changes(ctx context.Context, container containerd.Container, task containerd.Task, current, next *v1.Container, volumeRoot string) ([]change, error) {
+	var changes []change
+	image, err := newImageUpdateChange(ctx, a, container, next.Image)
+	if err != nil {
+		return nil, err
+	}
+	if image.kind() != kindNone {
+		changes = append(changes, image)
+	}
+	for name := range current.Services {
+		if _, ok := next.Services[name]; !ok {
+			// if the new config does not have a service, deregister the old one
+			changes = append(changes, &deregisterChange{
+				register: a.register,
+				name:     name,
+			})
+		}
+	}
+	if !proto.Equal(restartPolicy(current), restartPolicy(next)) ||
+		current.Type != next.Type || current.Schedule != next.Schedule ||
+		system.MaxStopTime(current) != system.MaxStopTime(next) {
+		changes = append(changes, &unitChange{
+			c: next,
+		})
+	}
+	if task != nil && cgroupResourcesChanged(current, next) {
+		changes = append(changes, &resourcesChange{
+			current: current.Resources,
+			next:    next.Resources,
+			task:    task,
+		})
+	}
+	if !proto.Equal(current.Allow, next.Allow) {
+		changes = append(changes, &policyChange{
+			c:     next,
+			agent: a,
+		})
+	}
+	if current.IngressRate != next.IngressRate || current.EgressRate != next.EgressRate {
+		changes = append(changes, &bandwidthChange{
+			c: next,
+		})
+	}
+	if !proto.Equal(networkFields(current), networkFields(next)) {
+		network, err := a.c.ContainerNetwork(current)
+		if err != nil {
+			return nil, err
+		}
+		// must be applied before the config so the current networks and port mappings are removed
+		changes = append(changes, &networkChange{
+			network: network,
+			next:    next,
+			agent:   a,
+		})
+	}
+	// the spec is generated again for a new image even when the config is the same
+	if configChanged := !proto.Equal(current, next); configChanged || image.kind() != kindNone {
+		changes = append(changes, &configChange{
+			client:     a.client,
+			c:          next,
+			current:    current,
+			volumeRoot: volumeRoot,
+		})
+		if configChanged {
+			changes = append(changes, &filesChange{
+				c:       next,
+				current: current,
+				store:   a.store,
+			})
+		}
+	}
+	// all services are registered again when the task is restarted
+	if task != nil && maxKind(changes) < kindRestart {
+		for name, s := range next.Services {
+			if cs, ok := current.Services[name]; ok && proto.Equal(cs, s) {
+				continue
 			}
-			return nil, wctx.Err()
-		case <-wait:
-			return &v1.UpdateResponse{}, nil
+			changes = append(changes, &registerChange{
+				register: a.register,
+				name:     name,
+				service:  s,
+			})
 		}
 	}
-	return &v1.UpdateResponse{}, nil
+	return changes, nil
 }
 
 func (a *Agent) Rollback(ctx context.Context, req *v1.RollbackRequest) (*v1.RollbackResponse, error) {
@@ -535,20 +766,32 @@ func (a *Agent) Rollback(ctx context.Context, req *v1.RollbackRequest) (*v1.Roll
 	if err != nil {
 		return nil, err
 	}
+	current, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+		return &v1.RollbackResponse{}, container.Update(ctx, flux.WithRollback, opts.WithRollback)
+	}
+	system.PreStop(ctx, container, task, current)
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
 	err = pauseAndRun(ctx, container, func() error {
 		if err := container.Update(ctx, flux.WithRollback, opts.WithRollback); err != nil {
 			return err
 		}
-		task, err := container.Task(ctx, nil)
-		if err != nil {
-			return err
-		}
-		return task.Kill(ctx, unix.SIGTERM)
+		return system.Signal(ctx, task, current)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &v1.RollbackResponse{}, nil
+	return &v1.RollbackResponse{}, system.WaitOrKill(ctx, task, wait, current)
 }
 
 func (a *Agent) PushBuild(ctx context.Context, req *v1.PushBuildRequest) (*types.Empty, error) {
@@ -615,6 +858,10 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 		Architecture: runtime.GOARCH,
 	}
 	index.Manifests = append(index.Manifests, desc)
+	config, err := opts.GetConfigFromInfo(ctx, info)
+	if err != nil {
+		return nil, err
+	}
 
 	opts := options.CheckpointOptions{
 		Exit:                req.Exit,
@@ -648,6 +895,16 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 			Architecture: runtime.GOARCH,
 		}
 		index.Manifests = append(index.Manifests, rw)
+		if req.Volumes {
+			layers, err := a.volumeLayers(ctx, config)
+			if err != nil {
+				return err
+			}
+			for _, l := range layers {
+				l.Platform = rw.Platform
+				index.Manifests = append(index.Manifests, l)
+			}
+		}
 		if req.Live {
 			task, err := a.client.TaskService().Checkpoint(ctx, &tasks.CheckpointTaskRequest{
 				ContainerID: req.ID,
@@ -695,7 +952,7 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 	return &v1.CheckpointResponse{}, nil
 }
 
-func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.RestoreResponse, error) {
+func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (_ *v1.RestoreResponse, err error) {
 	ctx = relayContext(ctx)
 	if req.Ref == "" {
 		return nil, ErrNoRef
@@ -716,18 +973,10 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 	if err != nil {
 		return nil, err
 	}
-	configDesc, err := getByMediaType(index, MediaTypeContainerInfo)
+	c, err := checkpointContainer(ctx, store, index)
 	if err != nil {
 		return nil, err
 	}
-	data, err := content.ReadBlob(ctx, store, *configDesc)
-	if err != nil {
-		return nil, err
-	}
-	var c containers.Container
-	if err := json.Unmarshal(data, &c); err != nil {
-		return nil, err
-	}
 	config, err := opts.GetConfigFromInfo(ctx, c)
 	if err != nil {
 		return nil, err
@@ -736,12 +985,24 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 	if err != nil {
 		return nil, err
 	}
+	if err := a.pullImages(ctx, config); err != nil {
+		return nil, err
+	}
 	volumeRoot, err := redis.String(a.doLocal("GET", v1.VolumeRootKey))
 	if err != nil && err != redis.ErrNil {
 		return nil, err
 	}
+	// the checkpoint is checked before anything is created for the container
+	rw, err := getLayer(index)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := volumeLayerRoots(index, config, volumeRoot, req.BindMounts)
+	if err != nil {
+		return nil, err
+	}
 	o := []containerd.NewContainerOpts{
-		flux.WithNewSnapshot(image),
+		withNewSnapshot(config, image),
 		opts.WithBossConfig(volumeRoot, config, image),
 	}
 	if req.Live {
@@ -751,22 +1012,31 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 		}
 		o = append(o, opts.WithRestore(desc))
 	}
+	ips, err := a.reserveIPs(config, req.From)
+	if err != nil {
+		return nil, err
+	}
 	container, err := a.client.NewContainer(ctx,
 		config.ID,
-		o...,
+		append(o, opts.WithReservedIPs(ips))...,
 	)
 	if err != nil {
+		a.releaseIPs(config)
 		return nil, err
 	}
+	// a failed restore can be retried without the container in the way
+	defer func() {
+		if err != nil {
+			systemd.RemoveDropIns(container.ID())
+			container.Delete(ctx, flux.WithRevisionCleanup)
+			a.releaseIPs(config)
+		}
+	}()
 	// apply rw layer
 	info, err := container.Info(ctx)
 	if err != nil {
 		return nil, err
 	}
-	rw, err := getByMediaType(index, is.MediaTypeImageLayerGzip)
-	if err != nil {
-		return nil, err
-	}
 	mounts, err := a.client.SnapshotService(info.Snapshotter).Mounts(ctx, info.SnapshotKey)
 	if err != nil {
 		return nil, err
@@ -774,11 +1044,13 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 	if _, err := a.client.DiffService().Apply(ctx, *rw, mounts); err != nil {
 		return nil, err
 	}
+	if err := applyVolumeLayers(ctx, store, layers); err != nil {
+		return nil, err
+	}
 	if err := a.store.Write(ctx, config); err != nil {
-		container.Delete(ctx, containerd.WithSnapshotCleanup)
 		return nil, err
 	}
-	if err := systemd.Enable(ctx, container.ID()); err != nil {
+	if err := setupUnit(ctx, config); err != nil {
 		return nil, err
 	}
 	if err := systemd.Start(ctx, container.ID()); err != nil {
@@ -802,26 +1074,60 @@ func (a *Agent) Migrate(ctx context.Context, req *v1.MigrateRequest) (*v1.Migrat
 	}); err == nil {
 		return nil, errServiceExistsOnTarget
 	}
-	if _, err := a.Checkpoint(ctx, &v1.CheckpointRequest{
-		ID:   req.ID,
-		Live: req.Live,
-		Ref:  req.Ref,
-		Exit: req.Stop || req.Delete,
-	}); err != nil {
-		return nil, err
+	// the address is only taken over from a task that is stopped,
+	// a source that keeps running keeps its address and the target reserves a new one
+	var from string
+	if req.Precopy || req.Stop || req.Delete {
+		from = a.c.ID
 	}
-	defer a.client.ImageService().Delete(ctx, req.Ref)
-	if _, err := a.Push(ctx, &v1.PushRequest{
-		Ref: req.Ref,
-	}); err != nil {
-		return nil, err
+	if req.Precopy {
+		resp, err := a.preCopy(ctx, req, to)
+		if err != nil {
+			return nil, err
+		}
+		if req.Delete {
+			if _, err := a.Delete(ctx, &v1.DeleteRequest{
+				ID: req.ID,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+	// without a ref the checkpoint is streamed to the target instead of a registry
+	ref := req.Ref
+	if ref == "" {
+		ref = migrationRef(req.ID)
 	}
-	if _, err := to.Restore(ctx, &v1.RestoreRequest{
-		Ref:  req.Ref,
-		Live: req.Live,
+	if _, err := a.Checkpoint(ctx, &v1.CheckpointRequest{
+		ID:      req.ID,
+		Live:    req.Live,
+		Ref:     ref,
+		Exit:    req.Stop || req.Delete,
+		Volumes: req.Volumes,
 	}); err != nil {
 		return nil, err
 	}
+	defer a.client.ImageService().Delete(ctx, ref)
+	if req.Ref == "" {
+		if err := a.transfer(ctx, to, ref, ref, true, req.Live, from, req.BindMounts); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := a.Push(ctx, &v1.PushRequest{
+			Ref: req.Ref,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := to.Restore(ctx, &v1.RestoreRequest{
+			Ref:        req.Ref,
+			Live:       req.Live,
+			From:       from,
+			BindMounts: req.BindMounts,
+		}); err != nil {
+			return nil, err
+		}
+	}
 	if req.Delete {
 		if _, err := a.Delete(ctx, &v1.DeleteRequest{
 			ID: req.ID,
@@ -858,11 +1164,27 @@ func (a *Agent) doLocal(action string, args ...interface{}) (interface{}, error)
 	return conn.Do(action, args...)
 }
 
+func (a *Agent) doMaster(action string, args ...interface{}) (interface{}, error) {
+	conn := a.master.Get()
+	defer conn.Close()
+	return conn.Do(action, args...)
+}
+
 var (
 	errServiceExistsOnTarget = errors.New("service exists on target")
 	errMediaTypeNotFound     = errors.New("media type not found in index")
 )
 
+// getLayer returns the rw layer of a checkpoint which is written uncompressed
+// by checkpoints and compressed when it was converted by a registry
+func getLayer(index *is.Index) (*is.Descriptor, error) {
+	d, err := getByMediaType(index, is.MediaTypeImageLayer)
+	if err == errMediaTypeNotFound {
+		return getByMediaType(index, is.MediaTypeImageLayerGzip)
+	}
+	return d, err
+}
+
 func getByMediaType(index *is.Index, mt string) (*is.Descriptor, error) {
 	for _, d := range index.Manifests {
 		if d.MediaType == mt {
@@ -872,6 +1194,25 @@ func getByMediaType(index *is.Index, mt string) (*is.Descriptor, error) {
 	return nil, errMediaTypeNotFound
 }
 
+// pullImages pulls the images for the container's init containers and sidecars
+func (a *Agent) pullImages(ctx context.Context, config *v1.Container) error {
+	var refs []string
+	for _, i := range config.Init {
+		refs = append(refs, i.Image)
+	}
+	for _, sc := range config.Sidecars {
+		if sc.Image != config.Image {
+			refs = append(refs, sc.Image)
+		}
+	}
+	for _, ref := range refs {
+		if _, err := a.client.Pull(ctx, ref, containerd.WithPullUnpack, a.withPlainRemote(ref)); err != nil {
+			return errors.Wrapf(err, "pull image %s", ref)
+		}
+	}
+	return nil
+}
+
 func (a *Agent) withPlainRemote(ref string) containerd.RemoteOpt {
 	remote := strings.SplitN(ref, "/", 2)[0]
 	return func(_ *containerd.Client, ctx *containerd.RemoteContext) error {
@@ -896,14 +1237,14 @@ func (a *Agent) handleResolvConf() error {
 	if err != nil {
 		return err
 	}
-	if err := writeResolvConf(append(peers, me)); err != nil {
+	if err := writeResolvConf(append(peers, me), a.c.Resolver); err != nil {
 		return err
 	}
 	c := make(chan *element.NodeEvent, 32)
 	a.node.Subscribe(c)
 	go func() {
 		for range c {
-			if err := writeResolvConf(append(peers, me)); err != nil {
+			if err := writeResolvConf(append(peers, me), a.c.Resolver); err != nil {
 				logrus.WithError(err).Error("update resolv config")
 			}
 		}
@@ -911,6 +1252,75 @@ func (a *Agent) handleResolvConf() error {
 	return nil
 }
 
+// reserveIPs reserves the container's addresses on the networks using the cluster ipam,
+// taking over the addresses held on the node it is migrated from
+func (a *Agent) reserveIPs(cfg *v1.Container, from string) (map[string]string, error) {
+	networks, err := a.c.ContainerNetworks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.IP != "" && (len(networks) == 0 || !networks[0].ClusterIPAM) {
+		return nil, errors.Errorf("ip can only be set when the primary network uses the %s ipam", config.ClusterIPAM)
+	}
+	ips := make(map[string]string)
+	for i, nw := range networks {
+		if !nw.ClusterIPAM {
+			continue
+		}
+		var (
+			ip      string
+			exclude []string
+		)
+		if i == 0 {
+			ip = cfg.IP
+		}
+		if from != "" {
+			moved, err := a.ipam.Move(nw.Name, from, cfg.ID)
+			if err != nil {
+				return nil, err
+			}
+			if moved != "" {
+				ip = moved
+			}
+		}
+		if nw.BridgeAddress != "" {
+			exclude = append(exclude, nw.BridgeAddress)
+		}
+		reserved, err := a.ipam.Reserve(nw.Name, nw.Subnet, cfg.ID, ip, exclude...)
+		if err != nil {
+			return nil, err
+		}
+		ips[nw.Name] = reserved
+	}
+	return ips, nil
+}
+
+// releaseIPs releases the container's addresses when it could not be created
+func (a *Agent) releaseIPs(cfg *v1.Container) {
+	networks, err := a.c.ContainerNetworks(cfg)
+	if err != nil {
+		return
+	}
+	for _, nw := range networks {
+		if !nw.ClusterIPAM {
+			continue
+		}
+		if err := a.ipam.Release(nw.Name, cfg.ID); err != nil {
+			logrus.WithError(err).Errorf("release ip on network %s", nw.Name)
+		}
+	}
+}
+
+// reconcileRoutes restores the host routes to containers on macvlan networks
+// that were lost while the agent was down and removes the orphaned ones
+func (a *Agent) reconcileRoutes() error {
+	networks, err := a.c.MacvlanNetworks()
+	if err != nil {
+		return err
+	}
+	return cni.Reconcile(relayContext(context.Background()), a.client, networks)
+}
+
 func getBindSizes(c *v1.Container) (size int64, _ error) {
 	for _, m := range c.Mounts {
 		f, err := os.Open(m.Source)
@@ -945,6 +1355,16 @@ func getBindSizes(c *v1.Container) (size int64, _ error) {
 	return size, nil
 }
 
+// withNewSnapshot creates the container's first revision, remapping the
+// image's filesystem when the container runs in a user namespace
+func withNewSnapshot(config *v1.Container, image containerd.Image) containerd.NewContainerOpts {
+	if config.Userns != nil {
+		uid, gid, size := opts.UserNamespaceIDs(config.Userns)
+		return flux.WithNewRemappedSnapshot(image, uid, gid, size)
+	}
+	return flux.WithNewSnapshot(image)
+}
+
 func relayContext(ctx context.Context) context.Context {
 	return namespaces.WithNamespace(ctx, v1.DefaultNamespace)
 }
@@ -981,6 +1401,20 @@ func writeContent(ctx context.Context, store content.Ingester, mediaType, ref st
 	}, nil
 }
 
+// checkpointContainer returns the container info saved in the checkpoint
+func checkpointContainer(ctx context.Context, store content.Provider, index *is.Index) (c containers.Container, err error) {
+	desc, err := getByMediaType(index, MediaTypeContainerInfo)
+	if err != nil {
+		return c, err
+	}
+	data, err := content.ReadBlob(ctx, store, *desc)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
 func decodeIndex(ctx context.Context, store content.Provider, desc is.Descriptor) (*is.Index, error) {
 	var index is.Index
 	p, err := content.ReadBlob(ctx, store, desc)
@@ -993,18 +1427,43 @@ func decodeIndex(ctx context.Context, store content.Provider, desc is.Descriptor
 	return &index, nil
 }
 
-func writeResolvConf(peers []*element.PeerAgent) error {
+// writeResolvConf writes the nameservers of the containers, the address of the resolver
+// when it listens on one or the nodes of the cluster
+func writeResolvConf(peers []*element.PeerAgent, r *config.Resolver) error {
+	var nameservers []string
+	if r != nil {
+		host, port, err := net.SplitHostPort(r.ListenAddress())
+		if err != nil {
+			return errors.Wrap(err, "resolver address")
+		}
+		// resolv.conf has no port for a nameserver
+		if port != "53" {
+			return errors.Errorf("resolver address %s must use port 53 to be used by containers", r.ListenAddress())
+		}
+		ip := net.ParseIP(host)
+		if ip != nil && ip.IsLoopback() {
+			return errors.Errorf("resolver address %s is not reachable from containers", r.ListenAddress())
+		}
+		if ip != nil && !ip.IsUnspecified() {
+			nameservers = append(nameservers, host)
+		}
+	}
+	if len(nameservers) == 0 {
+		for _, p := range peers {
+			host, _, err := net.SplitHostPort(p.Addr)
+			if err != nil {
+				return err
+			}
+			nameservers = append(nameservers, host)
+		}
+	}
 	f, err := os.OpenFile(filepath.Join(v1.Root, "resolv.conf"), os.O_TRUNC|os.O_WRONLY|os.O_CREATE, 0666)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	for _, p := range peers {
-		host, _, err := net.SplitHostPort(p.Addr)
-		if err != nil {
-			return err
-		}
-		if _, err := f.WriteString(fmt.Sprintf("nameserver %s\n", host)); err != nil {
+	for _, ns := range nameservers {
+		if _, err := f.WriteString(fmt.Sprintf("nameserver %s\n", ns)); err != nil {
 			return err
 		}
 	}
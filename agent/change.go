@@ -2,32 +2,85 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/cni"
 	"github.com/crosbymichael/boss/config"
 	"github.com/crosbymichael/boss/flux"
 	"github.com/crosbymichael/boss/opts"
+	"github.com/gogo/protobuf/proto"
+)
+
+// changeKind is how a change affects the running task
+type changeKind int
+
+const (
+	// kindNone changes are applied without touching the task
+	kindNone changeKind = iota
+	// kindReload changes are picked up by the task after a reload signal
+	kindReload
+	// kindRestart changes require the task to be restarted
+	kindRestart
+	// kindRevision changes require a new flux revision and a restart
+	kindRevision
 )
 
 type change interface {
+	kind() changeKind
 	update(context.Context, containerd.Container) error
 }
 
-type imageUpdateChange struct {
-	ref    string
-	client *containerd.Client
-	a      *Agent
+func maxKind(changes []change) (k changeKind) {
+	for _, ch := range changes {
+		if ck := ch.kind(); ck > k {
+			k = ck
+		}
+	}
+	return k
 }
 
-func (c *imageUpdateChange) update(ctx context.Context, container containerd.Container) error {
-	image, err := c.client.Pull(ctx, c.ref, containerd.WithPullUnpack, c.a.withPlainRemote(c.ref))
+// newImageUpdateChange pulls the image so that a new revision is only created
+// when the image differs from the one the current revision was created from
+func newImageUpdateChange(ctx context.Context, a *Agent, container containerd.Container, ref string) (*imageUpdateChange, error) {
+	image, err := a.client.Pull(ctx, ref, containerd.WithPullUnpack, a.withPlainRemote(ref))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := flux.IsCurrent(ctx, a.client, info, image)
+	if err != nil {
+		return nil, err
+	}
+	return &imageUpdateChange{
+		image:   image,
+		current: current,
+	}, nil
+}
+
+type imageUpdateChange struct {
+	image   containerd.Image
+	current bool
+}
+
+func (c *imageUpdateChange) kind() changeKind {
+	if c.current {
+		return kindNone
 	}
-	return container.Update(ctx, flux.WithUpgrade(image))
+	return kindRevision
+}
+
+// update creates the new revision and saves the current config with it so that
+// a rollback pairs the previous revision with the config it ran with
+func (c *imageUpdateChange) update(ctx context.Context, container containerd.Container) error {
+	return container.Update(ctx, opts.WithSetPreviousConfig, flux.WithUpgrade(c.image))
 }
 
 type deregisterChange struct {
@@ -35,33 +88,321 @@ type deregisterChange struct {
 	name     string
 }
 
+func (c *deregisterChange) kind() changeKind {
+	return kindNone
+}
+
 func (c *deregisterChange) update(ctx context.Context, container containerd.Container) error {
 	return c.register.Deregister(container.ID(), c.name)
 }
 
+// registerChange registers a new or changed service for a running task
+type registerChange struct {
+	register v1.Register
+	name     string
+	service  *v1.Service
+}
+
+func (c *registerChange) kind() changeKind {
+	return kindNone
+}
+
+func (c *registerChange) update(ctx context.Context, container containerd.Container) error {
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	ips := opts.IPs(labels)
+	if len(ips) == 0 {
+		return nil
+	}
+	if err := c.register.Register(container.ID(), c.name, ips, c.service); err != nil {
+		return err
+	}
+	return c.register.DisableMaintainance(container.ID(), c.name)
+}
+
+// unitChange updates the container's unit and timer without restarting the task
+type unitChange struct {
+	c *v1.Container
+}
+
+func (c *unitChange) kind() changeKind {
+	return kindNone
+}
+
+func (c *unitChange) update(ctx context.Context, container containerd.Container) error {
+	return setupUnit(ctx, c.c)
+}
+
+// policyChange replaces the network policy of the container's network namespace
+type policyChange struct {
+	c     *v1.Container
+	agent *Agent
+}
+
+func (c *policyChange) kind() changeKind {
+	return kindNone
+}
+
+func (c *policyChange) update(ctx context.Context, container containerd.Container) error {
+	return c.agent.applyPolicy(container, c.c)
+}
+
+// bandwidthChange changes the rate limits of the container's interfaces
+type bandwidthChange struct {
+	c *v1.Container
+}
+
+func (c *bandwidthChange) kind() changeKind {
+	return kindNone
+}
+
+func (c *bandwidthChange) update(ctx context.Context, container containerd.Container) error {
+	path := v1.NetworkPath(container.ID())
+	// the limits are set when the network is created
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return cni.SetBandwidth(path, c.c.IngressRate, c.c.EgressRate)
+}
+
+// networkChange removes the container's network so that it is setup
+// with the new config when the task is restarted
+type networkChange struct {
+	network v1.Network
+	next    *v1.Container
+	agent   *Agent
+}
+
+func (c *networkChange) kind() changeKind {
+	return kindRestart
+}
+
+func (c *networkChange) update(ctx context.Context, container containerd.Container) error {
+	if err := c.network.Remove(ctx, container); err != nil {
+		return err
+	}
+	// addresses are reserved after the current ones are released
+	ips, err := c.agent.reserveIPs(c.next, "")
+	if err != nil {
+		return err
+	}
+	return container.Update(ctx, opts.WithReservedIPs(ips))
+}
+
+// resourcesChange applies new cgroup resources to a running task
+type resourcesChange struct {
+	current *v1.Resources
+	next    *v1.Resources
+	task    containerd.Task
+}
+
+func (c *resourcesChange) kind() changeKind {
+	if removesLimits(c.current, c.next) {
+		return kindRestart
+	}
+	return kindNone
+}
+
+func (c *resourcesChange) update(ctx context.Context, container containerd.Container) error {
+	if c.kind() != kindNone {
+		return nil
+	}
+	resources, err := opts.Resources(c.next)
+	if err != nil {
+		return err
+	}
+	return c.task.Update(ctx, containerd.WithResources(resources))
+}
+
 type configChange struct {
 	c          *v1.Container
+	current    *v1.Container
 	client     *containerd.Client
 	volumeRoot string
 }
 
+func (c *configChange) kind() changeKind {
+	if proto.Equal(restartFields(c.current), restartFields(c.c)) {
+		return kindNone
+	}
+	return kindRestart
+}
+
 func (c *configChange) update(ctx context.Context, container containerd.Container) error {
 	image, err := c.client.GetImage(ctx, c.c.Image)
 	if err != nil {
 		return err
 	}
-	return container.Update(ctx, opts.WithSetPreviousConfig, opts.WithBossConfig(c.volumeRoot, c.c, image))
+	return container.Update(ctx, opts.WithBossConfig(c.volumeRoot, c.c, image))
 }
 
+// filesChange writes new config files to the store where the task's proxy
+// renders them and sends the config's signal
 type filesChange struct {
-	c     *v1.Container
-	store config.ConfigStore
+	c       *v1.Container
+	current *v1.Container
+	store   config.ConfigStore
+}
+
+func (c *filesChange) kind() changeKind {
+	for _, f := range c.changed() {
+		if f.Signal != "" {
+			return kindReload
+		}
+	}
+	return kindNone
 }
 
 func (c *filesChange) update(ctx context.Context, container containerd.Container) error {
+	for _, f := range c.changed() {
+		if err := c.store.Replace(ctx, f); err != nil {
+			return err
+		}
+	}
 	return c.store.Write(ctx, c.c)
 }
 
+// changed returns the existing config files that have new content
+func (c *filesChange) changed() (o []*v1.Config) {
+	for name, f := range c.c.Configs {
+		cf, ok := c.current.Configs[name]
+		if !ok || f.Content == "" || cf.Content == f.Content {
+			continue
+		}
+		o = append(o, f)
+	}
+	return o
+}
+
+// restartFields returns a copy of the config with only the fields that
+// require the task to be restarted when they change
+func restartFields(c *v1.Container) *v1.Container {
+	c = proto.Clone(c).(*v1.Container)
+	// images are handled by their own change
+	c.Image = ""
+	c.Services = nil
+	// init containers are run on the next start
+	c.Init = nil
+	// restart policies and schedules are applied to the unit
+	c.Restart = nil
+	c.Type = ""
+	c.Schedule = ""
+	// stop settings are read by the proxy when the task is stopped
+	c.StopSignal = ""
+	c.StopTimeout = 0
+	c.PreStop = nil
+	// network policies and rate limits are applied to the running task
+	c.Allow = nil
+	c.IngressRate = 0
+	c.EgressRate = 0
+	for _, f := range c.Configs {
+		f.Content = ""
+	}
+	if c.Resources != nil {
+		r := &v1.Resources{
+			Score:   c.Resources.Score,
+			NoFile:  c.Resources.NoFile,
+			Rlimits: c.Resources.Rlimits,
+		}
+		if proto.Equal(r, &v1.Resources{}) {
+			r = nil
+		}
+		c.Resources = r
+	}
+	return c
+}
+
+// networkFields returns the fields of the config that are applied when the network is setup
+func networkFields(c *v1.Container) *v1.Container {
+	return &v1.Container{
+		Network:  c.Network,
+		Networks: c.Networks,
+		Ports:    c.Ports,
+		IP:       c.IP,
+	}
+}
+
+// cgroupResourcesChanged returns true when the resources that can be applied
+// to a running task have changed
+func cgroupResourcesChanged(current, next *v1.Container) bool {
+	return !proto.Equal(cgroupResources(current.Resources), cgroupResources(next.Resources))
+}
+
+func cgroupResources(r *v1.Resources) *v1.Resources {
+	if r == nil {
+		return &v1.Resources{}
+	}
+	r = proto.Clone(r).(*v1.Resources)
+	r.Score = 0
+	r.NoFile = 0
+	r.Rlimits = nil
+	return r
+}
+
+// removesLimits returns true when a limit set on the task is no longer set,
+// as limits cannot be removed from a running task
+func removesLimits(current, next *v1.Resources) bool {
+	if current == nil {
+		return false
+	}
+	if next == nil {
+		next = &v1.Resources{}
+	}
+	return (current.Memory > 0 && next.Memory == 0) ||
+		(current.MemoryReservation > 0 && next.MemoryReservation == 0) ||
+		(current.MemorySwap > 0 && next.MemorySwap == 0) ||
+		(current.Cpus > 0 && next.Cpus == 0) ||
+		(current.CpusetCpus != "" && next.CpusetCpus == "") ||
+		(current.CpusetMems != "" && next.CpusetMems == "") ||
+		(current.Pids > 0 && next.Pids == 0) ||
+		(current.BlkioWeight > 0 && next.BlkioWeight == 0) ||
+		removesDevices(weightPaths(current.BlkioWeightDevices), weightPaths(next.BlkioWeightDevices)) ||
+		removesDevices(throttlePaths(current.DeviceReadBps), throttlePaths(next.DeviceReadBps)) ||
+		removesDevices(throttlePaths(current.DeviceWriteBps), throttlePaths(next.DeviceWriteBps)) ||
+		removesDevices(throttlePaths(current.DeviceReadIops), throttlePaths(next.DeviceReadIops)) ||
+		removesDevices(throttlePaths(current.DeviceWriteIops), throttlePaths(next.DeviceWriteIops))
+}
+
+// removesDevices returns true when a device of current is not in next.
+// Devices are compared by their numbers as different paths can name the same device
+// and a device that cannot be resolved is treated as removed.
+func removesDevices(current, next []string) bool {
+	devices := make(map[string]bool)
+	for _, path := range next {
+		major, minor, err := opts.DeviceNumbers(path)
+		if err != nil {
+			return true
+		}
+		devices[fmt.Sprintf("%d:%d", major, minor)] = true
+	}
+	for _, path := range current {
+		major, minor, err := opts.DeviceNumbers(path)
+		if err != nil || !devices[fmt.Sprintf("%d:%d", major, minor)] {
+			return true
+		}
+	}
+	return false
+}
+
+func weightPaths(devices []*v1.WeightDevice) (o []string) {
+	for _, d := range devices {
+		o = append(o, d.Path)
+	}
+	return o
+}
+
+func throttlePaths(devices []*v1.ThrottleDevice) (o []string) {
+	for _, d := range devices {
+		o = append(o, d.Path)
+	}
+	return o
+}
+
 func pauseAndRun(ctx context.Context, container containerd.Container, fn func() error) error {
 	task, err := container.Task(ctx, nil)
 	if err != nil {
@@ -0,0 +1,372 @@
+package agent
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/gogo/protobuf/types"
+	digest "github.com/opencontainers/go-digest"
+	ver "github.com/opencontainers/image-spec/specs-go"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Checkpoints lists the images that are checkpoints of containers
+func (a *Agent) Checkpoints(ctx context.Context, req *v1.CheckpointsRequest) (*v1.CheckpointsResponse, error) {
+	ctx = relayContext(ctx)
+	imgs, err := a.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.CheckpointsResponse
+	for _, i := range imgs {
+		if i.Target.MediaType != is.MediaTypeImageIndex {
+			continue
+		}
+		index, err := decodeIndex(ctx, a.client.ContentStore(), i.Target)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !isCheckpoint(index) {
+			continue
+		}
+		info, err := a.checkpointInfo(ctx, i, index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checkpoint %s", i.Name)
+		}
+		resp.Checkpoints = append(resp.Checkpoints, info)
+	}
+	return &resp, nil
+}
+
+func (a *Agent) InspectCheckpoint(ctx context.Context, req *v1.InspectCheckpointRequest) (*v1.InspectCheckpointResponse, error) {
+	ctx = relayContext(ctx)
+	image, index, err := a.getCheckpoint(ctx, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.checkpointInfo(ctx, image, index)
+	if err != nil {
+		return nil, err
+	}
+	c, err := checkpointContainer(ctx, a.client.ContentStore(), index)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfigFromInfo(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.InspectCheckpointResponse{
+		Checkpoint: info,
+		Config:     config,
+	}
+	for _, m := range index.Manifests {
+		resp.Blobs = append(resp.Blobs, blob(m))
+	}
+	return resp, nil
+}
+
+func (a *Agent) DeleteCheckpoint(ctx context.Context, req *v1.DeleteCheckpointRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if _, _, err := a.getCheckpoint(ctx, req.Ref); err != nil {
+		return nil, err
+	}
+	if err := a.client.ImageService().Delete(ctx, req.Ref); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// ExportCheckpoint streams the checkpoint as a tar of an oci image layout
+func (a *Agent) ExportCheckpoint(req *v1.ExportCheckpointRequest, stream v1.Agent_ExportCheckpointServer) error {
+	ctx := relayContext(stream.Context())
+	image, index, err := a.getCheckpoint(ctx, req.Ref)
+	if err != nil {
+		return err
+	}
+	var (
+		store  = a.client.ContentStore()
+		w      = bufio.NewWriterSize(&exportWriter{stream: stream}, chunkSize)
+		tw     = tar.NewWriter(w)
+		target = image.Target
+	)
+	target.Annotations = map[string]string{
+		is.AnnotationRefName: req.Ref,
+	}
+	if err := writeTarJSON(tw, "oci-layout", is.ImageLayout{
+		Version: is.ImageLayoutVersion,
+	}); err != nil {
+		return err
+	}
+	if err := writeTarJSON(tw, "index.json", is.Index{
+		Versioned: ver.Versioned{
+			SchemaVersion: 2,
+		},
+		Manifests: []is.Descriptor{target},
+	}); err != nil {
+		return err
+	}
+	written := make(map[digest.Digest]bool)
+	for _, desc := range append([]is.Descriptor{image.Target}, index.Manifests...) {
+		if written[desc.Digest] {
+			continue
+		}
+		written[desc.Digest] = true
+		if err := writeTarBlob(ctx, tw, store, desc); err != nil {
+			return errors.Wrapf(err, "export %s", desc.Digest)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// ImportCheckpoint stores the checkpoint of a tar of an oci image layout
+func (a *Agent) ImportCheckpoint(stream v1.Agent_ImportCheckpointServer) error {
+	ctx := relayContext(stream.Context())
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	var (
+		store  = a.client.ContentStore()
+		r      = &importReader{stream: stream}
+		tr     = tar.NewReader(r)
+		layout *is.Index
+	)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		switch {
+		case name == "oci-layout":
+			var l is.ImageLayout
+			if err := json.NewDecoder(tr).Decode(&l); err != nil {
+				return errors.Wrap(err, "decode oci-layout")
+			}
+			if l.Version != is.ImageLayoutVersion {
+				return errors.Errorf("unsupported oci layout version %q", l.Version)
+			}
+		case name == "index.json":
+			layout = &is.Index{}
+			if err := json.NewDecoder(tr).Decode(layout); err != nil {
+				return errors.Wrap(err, "decode index.json")
+			}
+		case strings.HasPrefix(name, "blobs/"):
+			parts := strings.Split(name, "/")
+			if len(parts) != 3 {
+				return errors.Errorf("invalid blob path %s", name)
+			}
+			dgst := digest.NewDigestFromHex(parts[1], parts[2])
+			if err := dgst.Validate(); err != nil {
+				return errors.Wrapf(err, "blob %s", name)
+			}
+			if err := content.WriteBlob(ctx, store, "import-"+dgst.String(), tr, is.Descriptor{
+				Digest: dgst,
+				Size:   hdr.Size,
+			}); err != nil {
+				return errors.Wrapf(err, "import %s", dgst)
+			}
+		}
+	}
+	// the stream may still hold the padding of the tar
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		return err
+	}
+	if layout == nil {
+		return errors.New("archive has no index.json")
+	}
+	if len(layout.Manifests) != 1 {
+		return errors.Errorf("archive has %d images, expected one checkpoint", len(layout.Manifests))
+	}
+	desc := layout.Manifests[0]
+	ref := r.ref
+	if ref == "" {
+		ref = desc.Annotations[is.AnnotationRefName]
+	}
+	if ref == "" {
+		return ErrNoRef
+	}
+	desc.Annotations = nil
+	index, err := decodeIndex(ctx, store, desc)
+	if err != nil {
+		return err
+	}
+	if !isCheckpoint(index) {
+		return errors.Errorf("%s is not a checkpoint", ref)
+	}
+	info := content.Info{
+		Digest: desc.Digest,
+		Labels: make(map[string]string),
+	}
+	var fields []string
+	for i, m := range index.Manifests {
+		if _, err := store.Info(ctx, m.Digest); err != nil {
+			return errors.Wrapf(err, "checkpoint blob %s", m.Digest)
+		}
+		label := fmt.Sprintf("containerd.io/gc.ref.content.%d", i)
+		info.Labels[label] = m.Digest.String()
+		fields = append(fields, "labels."+label)
+	}
+	if _, err := store.Update(ctx, info, fields...); err != nil {
+		return err
+	}
+	if err := a.setImage(ctx, ref, desc); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&v1.ImportCheckpointResponse{
+		Ref: ref,
+	})
+}
+
+// getCheckpoint returns the image and index of the checkpoint
+func (a *Agent) getCheckpoint(ctx context.Context, ref string) (images.Image, *is.Index, error) {
+	if ref == "" {
+		return images.Image{}, nil, ErrNoRef
+	}
+	image, err := a.client.ImageService().Get(ctx, ref)
+	if err != nil {
+		return images.Image{}, nil, err
+	}
+	notCheckpoint := errors.Errorf("%s is not a checkpoint", ref)
+	if image.Target.MediaType != is.MediaTypeImageIndex {
+		return images.Image{}, nil, notCheckpoint
+	}
+	index, err := decodeIndex(ctx, a.client.ContentStore(), image.Target)
+	if err != nil {
+		return images.Image{}, nil, err
+	}
+	if !isCheckpoint(index) {
+		return images.Image{}, nil, notCheckpoint
+	}
+	return image, index, nil
+}
+
+func (a *Agent) checkpointInfo(ctx context.Context, image images.Image, index *is.Index) (*v1.CheckpointInfo, error) {
+	c, err := checkpointContainer(ctx, a.client.ContentStore(), index)
+	if err != nil {
+		return nil, err
+	}
+	info := &v1.CheckpointInfo{
+		Ref:     image.Name,
+		ID:      c.ID,
+		Image:   c.Image,
+		Created: image.CreatedAt,
+		Size_:   image.Target.Size,
+	}
+	for _, m := range index.Manifests {
+		info.Size_ += m.Size
+		switch m.MediaType {
+		case images.MediaTypeContainerd1Checkpoint:
+			info.Live = true
+		case MediaTypeVolumeLayer:
+			info.Volumes = true
+		}
+	}
+	return info, nil
+}
+
+// isCheckpoint returns true if the index has the container info of a checkpoint
+func isCheckpoint(index *is.Index) bool {
+	_, err := getByMediaType(index, MediaTypeContainerInfo)
+	return err == nil
+}
+
+func writeTarJSON(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0444,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, bytes.NewReader(data))
+	return err
+}
+
+func writeTarBlob(ctx context.Context, tw *tar.Writer, store content.Provider, desc is.Descriptor) error {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     path.Join("blobs", desc.Digest.Algorithm().String(), desc.Digest.Hex()),
+		Mode:     0444,
+		Size:     ra.Size(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, io.NewSectionReader(ra, 0, ra.Size()))
+	return err
+}
+
+// exportWriter sends the written data to the client
+type exportWriter struct {
+	stream v1.Agent_ExportCheckpointServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&v1.ExportCheckpointResponse{
+		Data: p,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// importReader reads the data sent by the client
+type importReader struct {
+	stream v1.Agent_ImportCheckpointServer
+	ref    string
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if r.ref == "" {
+			r.ref = req.Ref
+		}
+		r.buf = req.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
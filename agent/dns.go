@@ -0,0 +1,181 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/resolver"
+	"github.com/gomodule/redigo/redis"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	dnsNodesKey = "io.boss.dns.nodes"
+	// recordsInterval is how often the node publishes its records
+	recordsInterval = 10 * time.Second
+	// recordsTTL expires the records of a node that stopped publishing
+	recordsTTL = 3 * recordsInterval
+	// recordsCacheTTL is how long the records of the cluster are answered from memory
+	recordsCacheTTL = 2 * time.Second
+)
+
+// recordsCache holds the records of the cluster so that queries are answered without the store
+type recordsCache struct {
+	mu      sync.Mutex
+	records *resolver.Records
+	loaded  time.Time
+}
+
+func (c *recordsCache) get(load func() (*resolver.Records, error)) (*resolver.Records, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.records != nil && time.Since(c.loaded) < recordsCacheTTL {
+		return c.records, nil
+	}
+	records, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.records, c.loaded = records, time.Now()
+	return records, nil
+}
+
+// invalidate loads the records on the next query
+func (c *recordsCache) invalidate() {
+	c.mu.Lock()
+	c.records = nil
+	c.mu.Unlock()
+}
+
+// PublishRecords publishes the addresses and services of the node's running
+// containers for the dns servers of the cluster until the context is canceled
+func (a *Agent) PublishRecords(ctx context.Context) {
+	ticker := time.NewTicker(recordsInterval)
+	defer ticker.Stop()
+	events, errs := a.client.Subscribe(relayContext(ctx), `topic~="/tasks/"`)
+	for {
+		if err := a.publishRecords(relayContext(ctx)); err != nil {
+			logrus.WithError(err).Error("publish dns records")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-events:
+		case err := <-errs:
+			if err != nil {
+				logrus.WithError(err).Error("dns record events")
+			}
+			// fall back to the interval
+			events, errs = nil, nil
+		}
+	}
+}
+
+func (a *Agent) publishRecords(ctx context.Context) error {
+	records, err := a.localRecords(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	if _, err := a.doMaster("SETEX", nodeRecordsKey(a.c.ID), int(recordsTTL/time.Second), data); err != nil {
+		return err
+	}
+	if _, err := a.doMaster("SADD", dnsNodesKey, a.c.ID); err != nil {
+		return err
+	}
+	// the node's own changes are answered on the next query
+	a.records.invalidate()
+	return nil
+}
+
+// localRecords returns the records of the node's running containers
+func (a *Agent) localRecords(ctx context.Context) (*resolver.Records, error) {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := &resolver.Records{
+		Containers: make(map[string][]string),
+		Services:   make(map[string][]resolver.Instance),
+	}
+	for _, c := range containers {
+		running, err := isRunning(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if !running {
+			continue
+		}
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ips := opts.IPs(info.Labels)
+		if len(ips) == 0 {
+			continue
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		records.Containers[c.ID()] = ips
+		records.Containers[resolver.Host(c.ID(), a.c.ID)] = ips
+		for name, s := range config.Services {
+			records.Services[name] = append(records.Services[name], resolver.Instance{
+				Container: c.ID(),
+				Node:      a.c.ID,
+				Port:      s.Port,
+			})
+		}
+	}
+	return records, nil
+}
+
+// Records returns the records published by all nodes of the cluster, cached for a short time
+func (a *Agent) Records() (*resolver.Records, error) {
+	return a.records.get(a.loadRecords)
+}
+
+func (a *Agent) loadRecords() (*resolver.Records, error) {
+	nodes, err := redis.Strings(a.doLocal("SMEMBERS", dnsNodesKey))
+	if err != nil {
+		return nil, err
+	}
+	records := &resolver.Records{}
+	if len(nodes) == 0 {
+		return records, nil
+	}
+	var keys []interface{}
+	for _, n := range nodes {
+		keys = append(keys, nodeRecordsKey(n))
+	}
+	values, err := redis.ByteSlices(a.doLocal("MGET", keys...))
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		// the records of the node expired
+		if v == nil {
+			continue
+		}
+		var r resolver.Records
+		if err := json.Unmarshal(v, &r); err != nil {
+			logrus.WithError(err).WithField("node", nodes[i]).Error("invalid dns records")
+			continue
+		}
+		records.Merge(&r)
+	}
+	return records, nil
+}
+
+func nodeRecordsKey(node string) string {
+	return fmt.Sprintf("io.boss.dns.node.%s", node)
+}
@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/system"
+	"github.com/crosbymichael/boss/systemd"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+)
+
+func (a *Agent) Jobs(ctx context.Context, req *v1.JobsRequest) (*v1.JobsResponse, error) {
+	ctx = relayContext(ctx)
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.JobsResponse
+	for _, c := range containers {
+		if req.ID != "" && c.ID() != req.ID {
+			continue
+		}
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d := info.Extensions[opts.CurrentConfig]
+		cfg, err := opts.UnmarshalConfig(&d)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Type != v1.JobType {
+			continue
+		}
+		history, err := opts.GetJobHistoryFromInfo(info)
+		if err != nil {
+			return nil, err
+		}
+		status, err := taskStatus(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		resp.Jobs = append(resp.Jobs, &v1.Job{
+			ID:       c.ID(),
+			Schedule: cfg.Schedule,
+			Status:   status,
+			Runs:     history.Runs,
+		})
+	}
+	if req.ID != "" && len(resp.Jobs) == 0 {
+		return nil, errors.Errorf("job %s does not exist", req.ID)
+	}
+	return &resp, nil
+}
+
+func (a *Agent) RunJob(ctx context.Context, req *v1.RunJobRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	container, err := a.client.LoadContainer(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	if config.Type != v1.JobType {
+		return nil, errors.Errorf("container %s is not a job", req.ID)
+	}
+	return empty, systemd.Start(ctx, req.ID)
+}
+
+// setupUnit configures how systemd runs the container.
+// Services are enabled to start on boot while jobs are run by their timer or on demand.
+func setupUnit(ctx context.Context, config *v1.Container) error {
+	if err := systemd.SetRestartPolicy(ctx, config.ID, restartPolicy(config)); err != nil {
+		return err
+	}
+	if err := systemd.SetStopTimeout(ctx, config.ID, system.MaxStopTime(config)); err != nil {
+		return err
+	}
+	if config.Type != v1.JobType {
+		if err := systemd.RemoveTimer(ctx, config.ID); err != nil {
+			return err
+		}
+		return systemd.Enable(ctx, config.ID)
+	}
+	if err := systemd.Disable(ctx, config.ID); err != nil {
+		return err
+	}
+	if config.Schedule == "" {
+		return systemd.RemoveTimer(ctx, config.ID)
+	}
+	return systemd.InstallTimer(ctx, config.ID, config.Schedule)
+}
+
+// restartPolicy returns the container's restart policy, jobs are not restarted by default
+func restartPolicy(config *v1.Container) *v1.RestartPolicy {
+	if config.Restart == nil && config.Type == v1.JobType {
+		return &v1.RestartPolicy{
+			Policy: "never",
+		}
+	}
+	return config.Restart
+}
+
+func taskStatus(ctx context.Context, c containerd.Container) (string, error) {
+	task, err := c.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return string(containerd.Stopped), nil
+		}
+		return "", err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(status.Status), nil
+}
@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/policy"
+	"github.com/sirupsen/logrus"
+)
+
+const policyInterval = 30 * time.Second
+
+// SyncPolicies refreshes the network policies that allow services so that
+// they follow the addresses of the services until the context is canceled
+func (a *Agent) SyncPolicies(ctx context.Context) {
+	ticker := time.NewTicker(policyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.syncPolicies(relayContext(ctx)); err != nil {
+				logrus.WithError(err).Error("sync network policies")
+			}
+		}
+	}
+}
+
+func (a *Agent) syncPolicies(ctx context.Context) error {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return err
+	}
+	synced := make(map[string]bool)
+	for _, c := range containers {
+		config, err := opts.GetConfig(ctx, c)
+		if err != nil {
+			return err
+		}
+		if !allowsServices(config.Allow) {
+			continue
+		}
+		synced[c.ID()] = true
+		if err := a.refreshPolicy(c, config); err != nil {
+			logrus.WithError(err).WithField("id", c.ID()).Error("apply network policy")
+		}
+	}
+	a.policies.retain(synced)
+	return nil
+}
+
+// refreshPolicy applies the container's policy only when the resolved rules changed
+func (a *Agent) refreshPolicy(c containerd.Container, config *v1.Container) error {
+	rules, err := policy.Rules(config.Allow, a.register.Resolve)
+	if err != nil {
+		return err
+	}
+	// the network namespace is replaced with its initial rules when the task restarts
+	applied := appliedPolicy{
+		rules: rules,
+		netns: netnsInode(v1.NetworkPath(c.ID())),
+	}
+	if a.policies.get(c.ID()) == applied {
+		return nil
+	}
+	return a.setPolicy(c.ID(), rules)
+}
+
+// applyPolicy replaces the rules of the container's network namespace with its policy
+func (a *Agent) applyPolicy(c containerd.Container, config *v1.Container) error {
+	if config.Allow == nil {
+		a.policies.remove(c.ID())
+		path := v1.NetworkPath(c.ID())
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		return policy.Remove(path)
+	}
+	rules, err := policy.Rules(config.Allow, a.register.Resolve)
+	if err != nil {
+		return err
+	}
+	return a.setPolicy(c.ID(), rules)
+}
+
+func (a *Agent) setPolicy(id, rules string) error {
+	path := v1.NetworkPath(id)
+	// the policy is applied when the network is created
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := policy.Apply(path, rules); err != nil {
+		return err
+	}
+	a.policies.set(id, appliedPolicy{
+		rules: rules,
+		netns: netnsInode(path),
+	})
+	return nil
+}
+
+// appliedPolicy is the ruleset last applied to a network namespace
+type appliedPolicy struct {
+	rules string
+	netns uint64
+}
+
+// appliedPolicies are the last rules applied to each container's network namespace
+type appliedPolicies struct {
+	mu    sync.Mutex
+	rules map[string]appliedPolicy
+}
+
+func (p *appliedPolicies) get(id string) appliedPolicy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rules[id]
+}
+
+func (p *appliedPolicies) set(id string, applied appliedPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rules == nil {
+		p.rules = make(map[string]appliedPolicy)
+	}
+	p.rules[id] = applied
+}
+
+func (p *appliedPolicies) remove(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.rules, id)
+}
+
+// retain removes the rules of containers that no longer allow services
+func (p *appliedPolicies) retain(ids map[string]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id := range p.rules {
+		if !ids[id] {
+			delete(p.rules, id)
+		}
+	}
+}
+
+func allowsServices(p *v1.Policy) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range append(p.Ingress, p.Egress...) {
+		if len(r.Services) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// netnsInode returns the inode of the network namespace mounted at the path or 0
+func netnsInode(path string) uint64 {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return fi.Sys().(*syscall.Stat_t).Ino
+}
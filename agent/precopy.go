@@ -0,0 +1,720 @@
+package agent
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/diff"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/rootfs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/systemd"
+	digest "github.com/opencontainers/go-digest"
+	ver "github.com/opencontainers/image-spec/specs-go"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	runcBinary = "/opt/containerd/bin/runc"
+	criuBinary = "/opt/containerd/bin/criu"
+	// runcRoot is the state directory of the containers run by the runc shim
+	runcRoot = "/run/containerd/runc"
+
+	defaultPreCopyRounds = 5
+	// convergedSize is a dirty set small enough to dump with little downtime
+	convergedSize = 8 << 20
+	// chunkSize is the size of the file data in a pre-copy message
+	chunkSize = 1 << 20
+
+	preCopyInfo = "container-info.json"
+	preCopyRW   = "rw.tar"
+	preCopyDump = "final"
+	// preCopyVolumes are the descriptors of the volume layers
+	preCopyVolumes = "volumes.json"
+)
+
+// preCopy migrates the container by dumping its memory in rounds while it runs, sending only
+// the pages dirtied since the previous round, until the dirty set converges and the container
+// is frozen for the final dump
+func (a *Agent) preCopy(ctx context.Context, req *v1.MigrateRequest, to v1.AgentClient) (*v1.MigrateResponse, error) {
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+	container, err := a.client.LoadContainer(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	dir := migrationPath(req.ID)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	stream, err := to.PreCopy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	max := req.MaxRounds
+	if max == 0 {
+		max = defaultPreCopyRounds
+	}
+	var (
+		resp v1.MigrateResponse
+		last int64
+	)
+	for n := uint32(1); n <= max; n++ {
+		var (
+			started = time.Now()
+			name    = filepath.Join("rounds", strconv.Itoa(int(n)))
+			parent  string
+		)
+		if n > 1 {
+			parent = filepath.Join("..", strconv.Itoa(int(n-1)))
+		}
+		if err := runcCheckpoint(req.ID, dir, name, parent, true); err != nil {
+			stream.CloseSend()
+			return nil, errors.Wrapf(err, "pre-dump round %d", n)
+		}
+		round, err := sendRound(stream, req.ID, dir, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "send round %d", n)
+		}
+		round.Round = n
+		round.Duration = time.Since(started)
+		resp.Rounds = append(resp.Rounds, round)
+		logrus.WithFields(logrus.Fields{
+			"id":    req.ID,
+			"round": n,
+			"dirty": round.Dirty,
+		}).Info("pre-dump round")
+		if converged(last, round.Dirty) {
+			break
+		}
+		last = round.Dirty
+	}
+	started := time.Now()
+	// the final dump stops the container so its rw layer no longer changes
+	parent := filepath.Join("..", "rounds", strconv.Itoa(len(resp.Rounds)))
+	if err := runcCheckpoint(req.ID, dir, preCopyDump, parent, false); err != nil {
+		stream.CloseSend()
+		return nil, errors.Wrap(err, "final dump")
+	}
+	if err := systemd.Stop(ctx, req.ID); err != nil {
+		logrus.WithError(err).WithField("id", req.ID).Error("stop service after final dump")
+	}
+	final, err := a.sendFinal(ctx, stream, container.ID(), dir, uint32(len(resp.Rounds)), req)
+	if err == nil {
+		_, err = stream.CloseAndRecv()
+	}
+	if err != nil {
+		// bring the container back up on this node rather than leaving it down
+		if serr := systemd.Start(ctx, req.ID); serr != nil {
+			logrus.WithError(serr).WithField("id", req.ID).Error("start container after failed migration")
+		}
+		return nil, errors.Wrap(err, "restore on target")
+	}
+	final.Round = uint32(len(resp.Rounds)) + 1
+	final.Final = true
+	final.Duration = time.Since(started)
+	resp.Rounds = append(resp.Rounds, final)
+	return &resp, nil
+}
+
+// sendFinal sends the final dump, the container info, the rw layer, and the volume layers
+// when the migration includes volumes, and asks the target to restore
+func (a *Agent) sendFinal(ctx context.Context, stream v1.Agent_PreCopyClient, id, dir string, rounds uint32, req *v1.MigrateRequest) (*v1.MigrateRound, error) {
+	round, err := sendRound(stream, id, dir, preCopyDump)
+	if err != nil {
+		return nil, err
+	}
+	container, err := a.client.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sendFile(stream, id, preCopyInfo, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	round.Sent += int64(len(data))
+	rw, err := rootfs.CreateDiff(ctx,
+		info.SnapshotKey,
+		a.client.SnapshotService(info.Snapshotter),
+		a.client.DiffService(),
+		diff.WithReference(fmt.Sprintf("checkpoint-rw-%s", info.SnapshotKey)),
+		diff.WithMediaType(is.MediaTypeImageLayer),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ra, err := a.client.ContentStore().ReaderAt(ctx, rw)
+	if err != nil {
+		return nil, err
+	}
+	defer ra.Close()
+	sent, err := sendFile(stream, id, preCopyRW, io.NewSectionReader(ra, 0, ra.Size()))
+	if err != nil {
+		return nil, err
+	}
+	round.Sent += sent
+	if req.Volumes {
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		if sent, err = a.sendVolumes(ctx, stream, config); err != nil {
+			return nil, err
+		}
+		round.Sent += sent
+	}
+	return round, stream.Send(&v1.PreCopyRequest{
+		ID:         id,
+		Restore:    true,
+		Rounds:     rounds,
+		From:       a.c.ID,
+		BindMounts: req.BindMounts,
+	})
+}
+
+// sendVolumes sends the layers of the container's volumes and their descriptors
+func (a *Agent) sendVolumes(ctx context.Context, stream v1.Agent_PreCopyClient, config *v1.Container) (sent int64, err error) {
+	layers, err := a.volumeLayers(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	for i, l := range layers {
+		ra, err := a.client.ContentStore().ReaderAt(ctx, l)
+		if err != nil {
+			return sent, err
+		}
+		n, err := sendFile(stream, config.ID, volumeLayerPath(i), io.NewSectionReader(ra, 0, ra.Size()))
+		ra.Close()
+		if err != nil {
+			return sent, err
+		}
+		sent += n
+	}
+	data, err := json.Marshal(layers)
+	if err != nil {
+		return sent, err
+	}
+	n, err := sendFile(stream, config.ID, preCopyVolumes, bytes.NewReader(data))
+	return sent + n, err
+}
+
+// PreCopy receives the rounds of a pre-copy migration and restores the container from the final dump
+func (a *Agent) PreCopy(stream v1.Agent_PreCopyServer) error {
+	ctx := relayContext(stream.Context())
+	var (
+		id, dir string
+		f       *receivedFile
+		restore *v1.PreCopyRequest
+	)
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+		if dir != "" {
+			os.RemoveAll(dir)
+		}
+	}()
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if dir == "" {
+			if req.ID == "" || strings.Contains(req.ID, "/") {
+				return ErrNoID
+			}
+			id, dir = req.ID, migrationPath(req.ID)
+			// remove the rounds of a migration that failed
+			if err := os.RemoveAll(dir); err != nil {
+				return err
+			}
+		}
+		if f != nil && req.Path != f.path {
+			return errors.Errorf("%s ended without a digest", f.path)
+		}
+		switch {
+		case req.Restore:
+			restore = req
+		case req.Digest != "":
+			if f == nil {
+				return errors.Errorf("digest of %s without data", req.Path)
+			}
+			err := f.verify(req.Digest)
+			f = nil
+			if err != nil {
+				return err
+			}
+		case req.Link != "":
+			if err := receiveLink(dir, req.Path, req.Link); err != nil {
+				return err
+			}
+		default:
+			if f == nil {
+				if f, err = createReceivedFile(dir, req.Path); err != nil {
+					return err
+				}
+			}
+			if _, err := f.Write(req.Data); err != nil {
+				return err
+			}
+		}
+	}
+	if f != nil {
+		return errors.Errorf("%s ended without a digest", f.path)
+	}
+	if restore == nil {
+		return errors.New("migration ended before the final dump")
+	}
+	if err := a.restorePreCopy(ctx, id, dir, restore); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&v1.PreCopyResponse{})
+}
+
+// receivedFile writes a file of the migration computing its digest
+type receivedFile struct {
+	*os.File
+	path     string
+	digester digest.Digester
+}
+
+// createReceivedFile creates the file within the migration without following symlinks
+func createReceivedFile(dir, path string) (*receivedFile, error) {
+	target, err := migrationFile(dir, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := mkdirNoSymlinks(dir, filepath.Dir(target)); err != nil {
+		return nil, err
+	}
+	// every file is sent once so an existing file or link is never written through
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL|syscall.O_NOFOLLOW, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &receivedFile{
+		File:     f,
+		path:     path,
+		digester: digest.Canonical.Digester(),
+	}, nil
+}
+
+func (f *receivedFile) Write(p []byte) (int, error) {
+	f.digester.Hash().Write(p)
+	return f.File.Write(p)
+}
+
+// verify closes the file and checks that its data matches the digest sent by the source
+func (f *receivedFile) verify(expected string) error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if actual := f.digester.Digest().String(); actual != expected {
+		return errors.Errorf("%s has digest %s, expected %s", f.path, actual, expected)
+	}
+	return nil
+}
+
+// receiveLink creates the parent link of a criu image directory.
+// Only relative links to another directory within the migration are accepted.
+func receiveLink(dir, path, link string) error {
+	target, err := migrationFile(dir, path)
+	if err != nil {
+		return err
+	}
+	if filepath.Base(target) != "parent" {
+		return errors.Errorf("unexpected link %s", path)
+	}
+	if filepath.IsAbs(link) {
+		return errors.Errorf("link %s is not relative", path)
+	}
+	resolved := filepath.Join(filepath.Dir(target), link)
+	if resolved == dir || !strings.HasPrefix(resolved, dir+string(filepath.Separator)) {
+		return errors.Errorf("link %s points outside of the migration", path)
+	}
+	if err := mkdirNoSymlinks(dir, filepath.Dir(target)); err != nil {
+		return err
+	}
+	return os.Symlink(link, target)
+}
+
+// mkdirNoSymlinks creates the directories from dir to path refusing to follow symlinks
+func mkdirNoSymlinks(dir, path string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+	current := dir
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, name)
+		fi, err := os.Lstat(current)
+		switch {
+		case os.IsNotExist(err):
+			if err := os.Mkdir(current, 0700); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case !fi.IsDir():
+			return errors.Errorf("%s is not a directory", current)
+		}
+	}
+	return nil
+}
+
+// restorePreCopy writes the received migration as a checkpoint and restores the container from it
+func (a *Agent) restorePreCopy(ctx context.Context, id, dir string, restore *v1.PreCopyRequest) error {
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	var (
+		store = a.client.ContentStore()
+		index = is.Index{
+			Versioned: ver.Versioned{
+				SchemaVersion: 2,
+			},
+			Annotations: make(map[string]string),
+		}
+		platform = &is.Platform{
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		}
+	)
+	for _, blob := range []struct {
+		name      string
+		mediaType string
+	}{
+		{preCopyInfo, MediaTypeContainerInfo},
+		{preCopyRW, is.MediaTypeImageLayer},
+	} {
+		f, err := os.Open(filepath.Join(dir, blob.name))
+		if err != nil {
+			return err
+		}
+		desc, err := writeContent(ctx, store, blob.mediaType, id+"-"+blob.name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		desc.Platform = platform
+		index.Manifests = append(index.Manifests, desc)
+	}
+	layers, err := receivedVolumes(ctx, store, id, dir)
+	if err != nil {
+		return err
+	}
+	for _, l := range layers {
+		l.Platform = platform
+		index.Manifests = append(index.Manifests, l)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeCheckpoint(pw, dir, restore.Rounds))
+	}()
+	desc, err := writeContent(ctx, store, images.MediaTypeContainerd1Checkpoint, id+"-checkpoint", pr)
+	pr.Close()
+	if err != nil {
+		return err
+	}
+	desc.Platform = platform
+	index.Manifests = append(index.Manifests, desc)
+	if desc, err = a.writeIndex(ctx, &index, id+"index"); err != nil {
+		return err
+	}
+	ref := migrationRef(id)
+	if _, err := a.client.ImageService().Create(ctx, images.Image{
+		Name:   ref,
+		Target: desc,
+	}); err != nil {
+		return err
+	}
+	defer a.client.ImageService().Delete(ctx, ref)
+	_, err = a.Restore(ctx, &v1.RestoreRequest{
+		Ref:        ref,
+		Live:       true,
+		From:       restore.From,
+		BindMounts: restore.BindMounts,
+	})
+	return err
+}
+
+// receivedVolumes writes the received volume layers to the content store verifying their digests
+func receivedVolumes(ctx context.Context, store content.Ingester, id, dir string) ([]is.Descriptor, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, preCopyVolumes))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var layers []is.Descriptor
+	if err := json.Unmarshal(data, &layers); err != nil {
+		return nil, err
+	}
+	for i, l := range layers {
+		f, err := os.Open(filepath.Join(dir, volumeLayerPath(i)))
+		if err != nil {
+			return nil, err
+		}
+		desc, err := writeContent(ctx, store, l.MediaType, fmt.Sprintf("%s-volume-%d", id, i), f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if desc.Digest != l.Digest {
+			return nil, errors.Errorf("volume layer %d does not match digest %s", i, l.Digest)
+		}
+	}
+	return layers, nil
+}
+
+// writeCheckpoint writes the final dump as a checkpoint tar with the pre-dump rounds
+// under rounds/ so that criu finds the pages of earlier rounds through the parent links
+func writeCheckpoint(w io.Writer, dir string, rounds uint32) error {
+	tw := tar.NewWriter(w)
+	if err := addDir(tw, filepath.Join(dir, preCopyDump), "", func(rel string) bool {
+		// the parent link is replaced with one within the checkpoint
+		return rel != "parent"
+	}); err != nil {
+		return err
+	}
+	if rounds > 0 {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeSymlink,
+			Name:     "parent",
+			Linkname: filepath.Join("rounds", strconv.Itoa(int(rounds))),
+			Mode:     0777,
+		}); err != nil {
+			return err
+		}
+		if err := addDir(tw, filepath.Join(dir, "rounds"), "rounds", nil); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func addDir(tw *tar.Writer, root, prefix string, include func(string) bool) error {
+	return filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || (include != nil && !include(rel)) {
+			return nil
+		}
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.Join(prefix, rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// runcCheckpoint dumps the container into the image directory under dir.
+// A pre-dump leaves the container running and tracks the pages it dirties for the next round.
+func runcCheckpoint(id, dir, name, parent string, preDump bool) error {
+	var (
+		image = filepath.Join(dir, name)
+		work  = filepath.Join(dir, "work", name)
+	)
+	for _, p := range []string{image, work} {
+		if err := os.MkdirAll(p, 0700); err != nil {
+			return err
+		}
+	}
+	args := []string{
+		"--root", filepath.Join(runcRoot, v1.DefaultNamespace),
+		"--criu", criuBinary,
+		"checkpoint",
+		"--image-path", image,
+		"--work-path", work,
+		"--file-locks",
+	}
+	if parent != "" {
+		args = append(args, "--parent-path", parent)
+	}
+	if preDump {
+		args = append(args, "--pre-dump")
+	}
+	out, err := exec.Command(runcBinary, append(args, id)...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "%s", out)
+	}
+	return nil
+}
+
+// sendRound sends the images of the round to the target
+func sendRound(stream v1.Agent_PreCopyClient, id, dir, name string) (*v1.MigrateRound, error) {
+	var round v1.MigrateRound
+	root := filepath.Join(dir, name)
+	if err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return stream.Send(&v1.PreCopyRequest{
+				ID:   id,
+				Path: rel,
+				Link: link,
+			})
+		case fi.Mode().IsRegular():
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			sent, err := sendFile(stream, id, rel, f)
+			if err != nil {
+				return err
+			}
+			round.Sent += sent
+			if isPages(fi.Name()) {
+				round.Dirty += fi.Size()
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+// sendFile sends the file in chunks followed by its digest,
+// an empty file is sent as a single empty chunk
+func sendFile(stream v1.Agent_PreCopyClient, id, path string, r io.Reader) (int64, error) {
+	var (
+		buf      = make([]byte, chunkSize)
+		sent     int64
+		digester = digest.Canonical.Digester()
+	)
+	r = io.TeeReader(r, digester.Hash())
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || sent == 0 {
+			if serr := stream.Send(&v1.PreCopyRequest{
+				ID:   id,
+				Path: path,
+				Data: buf[:n],
+			}); serr != nil {
+				return sent, serr
+			}
+			sent += int64(n)
+		}
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return sent, stream.Send(&v1.PreCopyRequest{
+					ID:     id,
+					Path:   path,
+					Digest: digester.Digest().String(),
+				})
+			}
+			return sent, err
+		}
+	}
+}
+
+// converged returns true when the dirty set is small or stopped shrinking
+// by at least a tenth between rounds
+func converged(last, dirty int64) bool {
+	if dirty <= convergedSize {
+		return true
+	}
+	return last > 0 && dirty*10 > last*9
+}
+
+func isPages(name string) bool {
+	return strings.HasPrefix(name, "pages-") && strings.HasSuffix(name, ".img")
+}
+
+// migrationRef is the name of the checkpoint of a migration that is not pushed to a registry
+func migrationRef(id string) string {
+	return fmt.Sprintf("migration/%s:latest", id)
+}
+
+func volumeLayerPath(i int) string {
+	return filepath.Join("volumes", strconv.Itoa(i)+".tar")
+}
+
+func migrationPath(id string) string {
+	return filepath.Join(v1.Root, "migrations", id)
+}
+
+// migrationFile returns the path of a received file ensuring that it is within the migration
+func migrationFile(dir, path string) (string, error) {
+	clean := filepath.Clean(filepath.Join("/", path))
+	if clean == "/" {
+		return "", errors.Errorf("invalid migration path %q", path)
+	}
+	return filepath.Join(dir, clean), nil
+}
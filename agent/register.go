@@ -0,0 +1,168 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/sirupsen/logrus"
+)
+
+const registerInterval = time.Minute
+
+// desiredService is a service that the node's containers should have registered
+type desiredService struct {
+	id      string
+	name    string
+	ips     []string
+	service *v1.Service
+	running bool
+}
+
+// SyncRegistrations reconciles the registered services with the containers
+// on startup and on an interval until the context is canceled
+func (a *Agent) SyncRegistrations(ctx context.Context) {
+	ticker := time.NewTicker(registerInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.syncRegistrations(relayContext(ctx)); err != nil {
+			logrus.WithError(err).Error("sync service registrations")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncRegistrations deregisters the services of containers that no longer exist,
+// registers the missing services, and sets the maintenance mode of each service
+// from the state of its task
+func (a *Agent) syncRegistrations(ctx context.Context) error {
+	desired, err := a.desiredServices(ctx)
+	if err != nil {
+		return err
+	}
+	registered, err := a.register.Registrations()
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*v1.Registration)
+	for _, r := range registered {
+		key := r.ID + "/" + r.Name
+		existing[key] = r
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		logrus.WithField("id", r.ID).WithField("service", r.Name).Info("deregister orphaned service")
+		if err := a.register.Deregister(r.ID, r.Name); err != nil {
+			return err
+		}
+	}
+	for key, d := range desired {
+		if err := a.syncRegistration(d, existing[key]); err != nil {
+			logrus.WithError(err).WithField("id", d.id).WithField("service", d.name).Error("sync service registration")
+		}
+	}
+	return nil
+}
+
+func (a *Agent) syncRegistration(d *desiredService, r *v1.Registration) error {
+	// stopped containers keep their services in maintenance until they are removed
+	if !d.running {
+		if r == nil && len(d.ips) > 0 {
+			logrus.WithField("id", d.id).WithField("service", d.name).Info("register missing service in maintenance")
+			if err := a.register.Register(d.id, d.name, d.ips, d.service); err != nil {
+				return err
+			}
+			return a.register.EnableMaintainance(d.id, d.name, v1.MaintenanceExited)
+		}
+		if r != nil && !r.Maintenance {
+			return a.register.EnableMaintainance(d.id, d.name, v1.MaintenanceExited)
+		}
+		return nil
+	}
+	if r == nil || !equalIPs(r.IPs, d.ips) {
+		logrus.WithField("id", d.id).WithField("service", d.name).Info("register missing service")
+		if err := a.register.Register(d.id, d.name, d.ips, d.service); err != nil {
+			return err
+		}
+		return a.register.DisableMaintainance(d.id, d.name)
+	}
+	// maintenance set on purpose, such as by a kill or an update, is kept
+	if r.Maintenance && (r.Reason == v1.MaintenanceCreated || r.Reason == v1.MaintenanceExited) {
+		return a.register.DisableMaintainance(d.id, d.name)
+	}
+	return nil
+}
+
+// desiredServices returns the services of the node's containers by id and name
+func (a *Agent) desiredServices(ctx context.Context) (map[string]*desiredService, error) {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	desired := make(map[string]*desiredService)
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		running, err := isRunning(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		ips := opts.IPs(info.Labels)
+		for name, s := range config.Services {
+			desired[c.ID()+"/"+name] = &desiredService{
+				id:      c.ID(),
+				name:    name,
+				ips:     ips,
+				service: s,
+				running: running && len(ips) > 0,
+			}
+		}
+	}
+	return desired, nil
+}
+
+func isRunning(ctx context.Context, c containerd.Container) (bool, error) {
+	task, err := c.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return status.Status == containerd.Running, nil
+}
+
+func equalIPs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
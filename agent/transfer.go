@@ -0,0 +1,308 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	digest "github.com/opencontainers/go-digest"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// transfer streams the checkpoint from the content store to the target agent which
+// stores it as ref and restores the container from it when restore is set.
+// The target takes over the addresses the container held on from when it is set.
+func (a *Agent) transfer(ctx context.Context, to v1.AgentClient, checkpoint, ref string, restore, live bool, from string, bindMounts []string) error {
+	image, err := a.client.GetImage(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	store := a.client.ContentStore()
+	index := image.Target()
+	data, err := content.ReadBlob(ctx, store, index)
+	if err != nil {
+		return err
+	}
+	var i is.Index
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+	stream, err := to.Transfer(ctx)
+	if err != nil {
+		return err
+	}
+	for n, m := range i.Manifests {
+		req := &v1.TransferRequest{}
+		if n == 0 {
+			req.Ref = ref
+		}
+		if err := sendBlob(ctx, stream, store, m, req); err != nil {
+			stream.CloseSend()
+			return errors.Wrapf(err, "send %s", m.Digest)
+		}
+	}
+	if err := stream.Send(&v1.TransferRequest{
+		Ref:        ref,
+		Index:      blob(index),
+		Data:       data,
+		Restore:    restore,
+		Live:       live,
+		From:       from,
+		BindMounts: bindMounts,
+	}); err != nil {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// sendBlob sends the blob's data in chunks after the first request
+func sendBlob(ctx context.Context, stream v1.Agent_TransferClient, store content.Provider, desc is.Descriptor, first *v1.TransferRequest) error {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	var (
+		r   = io.NewSectionReader(ra, 0, ra.Size())
+		buf = make([]byte, chunkSize)
+		req = first
+	)
+	req.Blob = blob(desc)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || req == first {
+			req.Data = buf[:n]
+			if serr := stream.Send(req); serr != nil {
+				return serr
+			}
+			req = &v1.TransferRequest{}
+		}
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Transfer receives a checkpoint from another agent verifying the digest of each blob
+func (a *Agent) Transfer(stream v1.Agent_TransferServer) error {
+	ctx := relayContext(stream.Context())
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	var (
+		store = a.client.ContentStore()
+		ref   string
+		w     *blobWriter
+	)
+	defer func() {
+		if w != nil {
+			w.Close()
+		}
+	}()
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("transfer ended before the checkpoint index")
+			}
+			return err
+		}
+		if req.Ref != "" {
+			ref = req.Ref
+		}
+		if req.Blob != nil {
+			if w != nil {
+				if err := w.commit(ctx); err != nil {
+					return err
+				}
+			}
+			if w, err = newBlobWriter(ctx, store, req.Blob); err != nil {
+				return err
+			}
+		}
+		if req.Index != nil {
+			if w != nil {
+				if err := w.commit(ctx); err != nil {
+					return err
+				}
+				w = nil
+			}
+			if ref == "" {
+				return ErrNoRef
+			}
+			if err := a.receiveIndex(ctx, ref, req); err != nil {
+				return err
+			}
+			return stream.SendAndClose(&v1.TransferResponse{})
+		}
+		if w == nil {
+			return errors.New("checkpoint data sent before its blob")
+		}
+		if err := w.write(req.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// receiveIndex stores the index as the checkpoint's image and restores the container from it
+func (a *Agent) receiveIndex(ctx context.Context, ref string, req *v1.TransferRequest) error {
+	desc, err := descriptor(req.Index)
+	if err != nil {
+		return err
+	}
+	if digest.FromBytes(req.Data) != desc.Digest {
+		return errors.Errorf("checkpoint index does not match digest %s", desc.Digest)
+	}
+	var index is.Index
+	if err := json.Unmarshal(req.Data, &index); err != nil {
+		return err
+	}
+	store := a.client.ContentStore()
+	for _, m := range index.Manifests {
+		if _, err := store.Info(ctx, m.Digest); err != nil {
+			return errors.Wrapf(err, "checkpoint blob %s", m.Digest)
+		}
+	}
+	labels := map[string]string{}
+	for i, m := range index.Manifests {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i)] = m.Digest.String()
+	}
+	if err := content.WriteBlob(ctx, store, ref+"-index", bytes.NewReader(req.Data), desc, content.WithLabels(labels)); err != nil {
+		return err
+	}
+	if err := a.setImage(ctx, ref, desc); err != nil {
+		return err
+	}
+	if !req.Restore {
+		return nil
+	}
+	// the restored container keeps a reference to its checkpoint
+	defer a.client.ImageService().Delete(ctx, ref)
+	_, err = a.Restore(ctx, &v1.RestoreRequest{
+		Ref:        ref,
+		Live:       req.Live,
+		From:       req.From,
+		BindMounts: req.BindMounts,
+	})
+	return err
+}
+
+// setImage creates the image or replaces the target of an existing image
+func (a *Agent) setImage(ctx context.Context, ref string, desc is.Descriptor) error {
+	i := images.Image{
+		Name:   ref,
+		Target: desc,
+	}
+	if _, err := a.client.ImageService().Create(ctx, i); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err := a.client.ImageService().Update(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobWriter writes a received blob to the content store, discarding the data of
+// blobs that already exist
+type blobWriter struct {
+	desc    is.Descriptor
+	writer  content.Writer
+	written int64
+}
+
+func newBlobWriter(ctx context.Context, store content.Ingester, b *v1.Blob) (*blobWriter, error) {
+	desc, err := descriptor(b)
+	if err != nil {
+		return nil, err
+	}
+	w := &blobWriter{
+		desc: desc,
+	}
+	writer, err := store.Writer(ctx, content.WithRef("transfer-"+desc.Digest.String()), content.WithDescriptor(desc))
+	if err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return w, nil
+		}
+		return nil, err
+	}
+	// restart a transfer that was interrupted
+	if err := writer.Truncate(0); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	w.writer = writer
+	return w, nil
+}
+
+func (w *blobWriter) write(data []byte) error {
+	w.written += int64(len(data))
+	if w.written > w.desc.Size {
+		return errors.Errorf("blob %s is larger than %d bytes", w.desc.Digest, w.desc.Size)
+	}
+	if w.writer == nil {
+		return nil
+	}
+	_, err := w.writer.Write(data)
+	return err
+}
+
+// commit verifies the size and digest of the blob
+func (w *blobWriter) commit(ctx context.Context) error {
+	defer w.Close()
+	if w.written != w.desc.Size {
+		return errors.Errorf("blob %s has %d of %d bytes", w.desc.Digest, w.written, w.desc.Size)
+	}
+	if w.writer == nil {
+		return nil
+	}
+	if err := w.writer.Commit(ctx, w.desc.Size, w.desc.Digest); err != nil && !errdefs.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "commit blob %s", w.desc.Digest)
+	}
+	return nil
+}
+
+func (w *blobWriter) Close() error {
+	if w.writer == nil {
+		return nil
+	}
+	err := w.writer.Close()
+	w.writer = nil
+	return err
+}
+
+func blob(desc is.Descriptor) *v1.Blob {
+	return &v1.Blob{
+		MediaType:   desc.MediaType,
+		Digest:      desc.Digest.String(),
+		Size_:       desc.Size,
+		Annotations: desc.Annotations,
+	}
+}
+
+func descriptor(b *v1.Blob) (is.Descriptor, error) {
+	d, err := digest.Parse(b.Digest)
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	return is.Descriptor{
+		MediaType:   b.MediaType,
+		Digest:      d,
+		Size:        b.Size_,
+		Annotations: b.Annotations,
+	}, nil
+}
@@ -0,0 +1,309 @@
+package agent
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/cgroups"
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/gomodule/redigo/redis"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MediaTypeVolumeLayer is a tar of the content of a volume or bind mount
+	MediaTypeVolumeLayer = "application/vnd.boss.volume.layer.v1.tar"
+	// VolumeAnnotation is the id of the volume of a layer, it is restored under the volume root
+	VolumeAnnotation = "io.boss.volume"
+	// MountRootAnnotation is the host directory where the layer of a bind mount is restored
+	MountRootAnnotation = "io.boss.mount.root"
+	// MountSourceAnnotation is the source of the bind mount of a layer
+	MountSourceAnnotation = "io.boss.mount.source"
+)
+
+func (a *Agent) EstimateCheckpoint(ctx context.Context, req *v1.EstimateCheckpointRequest) (*v1.EstimateCheckpointResponse, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	container, err := a.client.LoadContainer(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := a.client.SnapshotService(info.Snapshotter).Usage(ctx, info.SnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.EstimateCheckpointResponse{
+		RW: usage.Size,
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		stats, err := task.Metrics(ctx)
+		if err != nil {
+			return nil, err
+		}
+		v, err := typeurl.UnmarshalAny(stats.Data)
+		if err != nil {
+			return nil, err
+		}
+		if cg := v.(*cgroups.Metrics); cg.Memory != nil && cg.Memory.Usage != nil {
+			// the page cache is not dumped, it can exceed the usage when it is charged to the parent
+			if usage := cg.Memory.Usage.Usage; usage > cg.Memory.TotalCache {
+				resp.Memory = int64(usage - cg.Memory.TotalCache)
+			}
+		}
+	}
+	resp.Total = resp.RW + resp.Memory
+	if !req.Volumes {
+		return resp, nil
+	}
+	config, err := opts.GetConfigFromInfo(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := a.volumeSources(config)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sources {
+		size, err := dirSize(s.path)
+		if err != nil {
+			return nil, err
+		}
+		resp.Volumes = append(resp.Volumes, &v1.VolumeEstimate{
+			Name:  s.name,
+			Size_: size,
+		})
+		resp.Total += size
+	}
+	return resp, nil
+}
+
+// volumeSource is a host path of a container's volume or bind mount
+type volumeSource struct {
+	name        string
+	path        string
+	annotations map[string]string
+}
+
+func (a *Agent) volumeSources(config *v1.Container) ([]volumeSource, error) {
+	var sources []volumeSource
+	if len(config.Volumes) > 0 {
+		volumeRoot, err := redis.String(a.doLocal("GET", v1.VolumeRootKey))
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+		if volumeRoot == "" {
+			return nil, errors.New("no volume_root specified")
+		}
+		for _, v := range config.Volumes {
+			sources = append(sources, volumeSource{
+				name: v.ID,
+				path: filepath.Join(volumeRoot, v.ID),
+				annotations: map[string]string{
+					VolumeAnnotation: v.ID,
+				},
+			})
+		}
+	}
+	for _, m := range config.Mounts {
+		if m.Type != "bind" {
+			continue
+		}
+		fi, err := os.Stat(m.Source)
+		if err != nil {
+			return nil, err
+		}
+		root := m.Source
+		if !fi.IsDir() {
+			root = filepath.Dir(m.Source)
+		}
+		sources = append(sources, volumeSource{
+			name: m.Source,
+			path: m.Source,
+			annotations: map[string]string{
+				MountRootAnnotation:   root,
+				MountSourceAnnotation: m.Source,
+			},
+		})
+	}
+	return sources, nil
+}
+
+// volumeLayers writes the content of the container's volumes and bind mounts
+// as layers in the content store
+func (a *Agent) volumeLayers(ctx context.Context, config *v1.Container) ([]is.Descriptor, error) {
+	sources, err := a.volumeSources(config)
+	if err != nil {
+		return nil, err
+	}
+	var layers []is.Descriptor
+	for i, s := range sources {
+		pr, pw := io.Pipe()
+		go func(path string) {
+			pw.CloseWithError(writeVolume(ctx, pw, path))
+		}(s.path)
+		desc, err := writeContent(ctx, a.client.ContentStore(), MediaTypeVolumeLayer, fmt.Sprintf("%s-volume-%d", config.ID, i), pr)
+		pr.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "write layer of %s", s.name)
+		}
+		desc.Annotations = s.annotations
+		layers = append(layers, desc)
+	}
+	return layers, nil
+}
+
+// volumeLayer is a volume layer of a checkpoint and the host directory it is restored to
+type volumeLayer struct {
+	desc is.Descriptor
+	root string
+}
+
+// volumeLayerRoots returns where the volume layers of the checkpoint are restored.
+// Volumes are restored under the node's volume root and bind mounts at their sources
+// only when listed in bindMounts.
+func volumeLayerRoots(index *is.Index, config *v1.Container, volumeRoot string, bindMounts []string) ([]volumeLayer, error) {
+	var (
+		volumes = make(map[string]bool)
+		binds   = make(map[string]bool)
+		optIn   = make(map[string]bool)
+	)
+	for _, v := range config.Volumes {
+		volumes[v.ID] = true
+	}
+	for _, m := range config.Mounts {
+		if m.Type == "bind" {
+			binds[m.Source] = true
+		}
+	}
+	for _, b := range bindMounts {
+		if !binds[b] {
+			return nil, errors.Errorf("%s is not a bind mount of %s", b, config.ID)
+		}
+		optIn[b] = true
+	}
+	var (
+		layers   []volumeLayer
+		restored = make(map[string]bool)
+	)
+	for _, m := range index.Manifests {
+		if m.MediaType != MediaTypeVolumeLayer {
+			continue
+		}
+		var root string
+		if id := m.Annotations[VolumeAnnotation]; id != "" {
+			if !volumes[id] {
+				return nil, errors.Errorf("volume layer %s is not a volume of %s", m.Digest, config.ID)
+			}
+			if volumeRoot == "" {
+				return nil, errors.New("no volume_root specified")
+			}
+			root = filepath.Join(volumeRoot, id)
+		} else {
+			source := m.Annotations[MountSourceAnnotation]
+			if !binds[source] {
+				return nil, errors.Errorf("volume layer %s is not a bind mount of %s", m.Digest, config.ID)
+			}
+			if !optIn[source] {
+				logrus.WithField("source", source).Warn("skip bind mount that is not restored")
+				continue
+			}
+			root = m.Annotations[MountRootAnnotation]
+			if root != source && root != filepath.Dir(source) {
+				return nil, errors.Errorf("volume layer %s restores %s outside of bind mount %s", m.Digest, root, source)
+			}
+			restored[source] = true
+		}
+		layers = append(layers, volumeLayer{
+			desc: m,
+			root: root,
+		})
+	}
+	for _, b := range bindMounts {
+		if !restored[b] {
+			return nil, errors.Errorf("checkpoint has no layer of bind mount %s", b)
+		}
+	}
+	return layers, nil
+}
+
+// applyVolumeLayers restores the volume layers at their roots
+func applyVolumeLayers(ctx context.Context, store content.Provider, layers []volumeLayer) error {
+	for _, l := range layers {
+		if err := os.MkdirAll(l.root, 0711); err != nil {
+			return err
+		}
+		ra, err := store.ReaderAt(ctx, l.desc)
+		if err != nil {
+			return err
+		}
+		_, err = archive.Apply(ctx, l.root, io.NewSectionReader(ra, 0, ra.Size()))
+		ra.Close()
+		if err != nil {
+			return errors.Wrapf(err, "apply volume layer to %s", l.root)
+		}
+	}
+	return nil
+}
+
+// writeVolume writes the directory as a tar diff or a single file as a tar with the file
+func writeVolume(ctx context.Context, w io.Writer, path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return archive.WriteDiff(ctx, w, "", path)
+	}
+	tw := tar.NewWriter(w)
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(tw, f); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// dirSize returns the size of the files under the path
+func dirSize(path string) (size int64, _ error) {
+	err := filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -41,7 +41,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{0}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{0}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -86,7 +86,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{1}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{1}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -124,7 +124,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{2}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{2}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -162,7 +162,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{3}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{3}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *KillRequest) Reset()         { *m = KillRequest{} }
 func (m *KillRequest) String() string { return proto.CompactTextString(m) }
 func (*KillRequest) ProtoMessage()    {}
 func (*KillRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{4}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{4}
 }
 func (m *KillRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KillRequest.Unmarshal(m, b)
@@ -245,7 +245,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{5}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{5}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -276,7 +276,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{6}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{6}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -313,7 +313,7 @@ func (m *NodesRequest) Reset()         { *m = NodesRequest{} }
 func (m *NodesRequest) String() string { return proto.CompactTextString(m) }
 func (*NodesRequest) ProtoMessage()    {}
 func (*NodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{7}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{7}
 }
 func (m *NodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodesRequest.Unmarshal(m, b)
@@ -344,7 +344,7 @@ func (m *NodesResponse) Reset()         { *m = NodesResponse{} }
 func (m *NodesResponse) String() string { return proto.CompactTextString(m) }
 func (*NodesResponse) ProtoMessage()    {}
 func (*NodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{8}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{8}
 }
 func (m *NodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodesResponse.Unmarshal(m, b)
@@ -384,7 +384,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{9}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{9}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -426,28 +426,34 @@ func (m *Node) GetLabels() map[string]string {
 }
 
 type ContainerInfo struct {
-	ID                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Image                string      `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Status               string      `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	IP                   string      `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
-	Cpu                  uint64      `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	MemoryUsage          float64     `protobuf:"fixed64,6,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
-	MemoryLimit          float64     `protobuf:"fixed64,7,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
-	PidUsage             uint64      `protobuf:"varint,8,opt,name=pid_usage,json=pidUsage,proto3" json:"pid_usage,omitempty"`
-	PidLimit             uint64      `protobuf:"varint,9,opt,name=pid_limit,json=pidLimit,proto3" json:"pid_limit,omitempty"`
-	FsSize               int64       `protobuf:"varint,10,opt,name=fs_size,json=fsSize,proto3" json:"fs_size,omitempty"`
-	Config               *Container  `protobuf:"bytes,11,opt,name=config" json:"config,omitempty"`
-	Snapshots            []*Snapshot `protobuf:"bytes,12,rep,name=snapshots" json:"snapshots,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	ID                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Image                string            `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Status               string            `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	IP                   string            `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	Cpu                  uint64            `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryUsage          float64           `protobuf:"fixed64,6,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	MemoryLimit          float64           `protobuf:"fixed64,7,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
+	PidUsage             uint64            `protobuf:"varint,8,opt,name=pid_usage,json=pidUsage,proto3" json:"pid_usage,omitempty"`
+	PidLimit             uint64            `protobuf:"varint,9,opt,name=pid_limit,json=pidLimit,proto3" json:"pid_limit,omitempty"`
+	FsSize               int64             `protobuf:"varint,10,opt,name=fs_size,json=fsSize,proto3" json:"fs_size,omitempty"`
+	Config               *Container        `protobuf:"bytes,11,opt,name=config" json:"config,omitempty"`
+	Snapshots            []*Snapshot       `protobuf:"bytes,12,rep,name=snapshots" json:"snapshots,omitempty"`
+	Restarts             uint64            `protobuf:"varint,13,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	Failures             uint64            `protobuf:"varint,14,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastExitCode         int32             `protobuf:"varint,15,opt,name=last_exit_code,json=lastExitCode,proto3" json:"last_exit_code,omitempty"`
+	Sidecars             []*SidecarInfo    `protobuf:"bytes,16,rep,name=sidecars" json:"sidecars,omitempty"`
+	Networks             map[string]string `protobuf:"bytes,17,rep,name=networks" json:"networks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IPv6                 string            `protobuf:"bytes,18,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ContainerInfo) Reset()         { *m = ContainerInfo{} }
 func (m *ContainerInfo) String() string { return proto.CompactTextString(m) }
 func (*ContainerInfo) ProtoMessage()    {}
 func (*ContainerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{10}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{10}
 }
 func (m *ContainerInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContainerInfo.Unmarshal(m, b)
@@ -551,6 +557,102 @@ func (m *ContainerInfo) GetSnapshots() []*Snapshot {
 	return nil
 }
 
+func (m *ContainerInfo) GetRestarts() uint64 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
+func (m *ContainerInfo) GetFailures() uint64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *ContainerInfo) GetLastExitCode() int32 {
+	if m != nil {
+		return m.LastExitCode
+	}
+	return 0
+}
+
+func (m *ContainerInfo) GetSidecars() []*SidecarInfo {
+	if m != nil {
+		return m.Sidecars
+	}
+	return nil
+}
+
+func (m *ContainerInfo) GetNetworks() map[string]string {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
+}
+
+func (m *ContainerInfo) GetIPv6() string {
+	if m != nil {
+		return m.IPv6
+	}
+	return ""
+}
+
+type SidecarInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image                string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SidecarInfo) Reset()         { *m = SidecarInfo{} }
+func (m *SidecarInfo) String() string { return proto.CompactTextString(m) }
+func (*SidecarInfo) ProtoMessage()    {}
+func (*SidecarInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{11}
+}
+func (m *SidecarInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SidecarInfo.Unmarshal(m, b)
+}
+func (m *SidecarInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SidecarInfo.Marshal(b, m, deterministic)
+}
+func (dst *SidecarInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SidecarInfo.Merge(dst, src)
+}
+func (m *SidecarInfo) XXX_Size() int {
+	return xxx_messageInfo_SidecarInfo.Size(m)
+}
+func (m *SidecarInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SidecarInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SidecarInfo proto.InternalMessageInfo
+
+func (m *SidecarInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SidecarInfo) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *SidecarInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
 type Snapshot struct {
 	ID                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Created              time.Time `protobuf:"bytes,2,opt,name=created,stdtime" json:"created"`
@@ -565,7 +667,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{11}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{12}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
@@ -624,7 +726,7 @@ func (m *RollbackRequest) Reset()         { *m = RollbackRequest{} }
 func (m *RollbackRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackRequest) ProtoMessage()    {}
 func (*RollbackRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{12}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{13}
 }
 func (m *RollbackRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackRequest.Unmarshal(m, b)
@@ -662,7 +764,7 @@ func (m *RollbackResponse) Reset()         { *m = RollbackResponse{} }
 func (m *RollbackResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackResponse) ProtoMessage()    {}
 func (*RollbackResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{13}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{14}
 }
 func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackResponse.Unmarshal(m, b)
@@ -700,7 +802,7 @@ func (m *StartRequest) Reset()         { *m = StartRequest{} }
 func (m *StartRequest) String() string { return proto.CompactTextString(m) }
 func (*StartRequest) ProtoMessage()    {}
 func (*StartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{14}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{15}
 }
 func (m *StartRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StartRequest.Unmarshal(m, b)
@@ -738,7 +840,7 @@ func (m *StopRequest) Reset()         { *m = StopRequest{} }
 func (m *StopRequest) String() string { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()    {}
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{15}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{16}
 }
 func (m *StopRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StopRequest.Unmarshal(m, b)
@@ -776,7 +878,7 @@ func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{16}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{17}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -805,6 +907,7 @@ func (m *UpdateRequest) GetContainer() *Container {
 
 type UpdateResponse struct {
 	Container            *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	Action               string     `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -814,7 +917,7 @@ func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{17}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{18}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -841,6 +944,13 @@ func (m *UpdateResponse) GetContainer() *Container {
 	return nil
 }
 
+func (m *UpdateResponse) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
 type PushBuildRequest struct {
 	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -852,7 +962,7 @@ func (m *PushBuildRequest) Reset()         { *m = PushBuildRequest{} }
 func (m *PushBuildRequest) String() string { return proto.CompactTextString(m) }
 func (*PushBuildRequest) ProtoMessage()    {}
 func (*PushBuildRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{18}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{19}
 }
 func (m *PushBuildRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PushBuildRequest.Unmarshal(m, b)
@@ -891,7 +1001,7 @@ func (m *PushRequest) Reset()         { *m = PushRequest{} }
 func (m *PushRequest) String() string { return proto.CompactTextString(m) }
 func (*PushRequest) ProtoMessage()    {}
 func (*PushRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{19}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{20}
 }
 func (m *PushRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PushRequest.Unmarshal(m, b)
@@ -926,10 +1036,12 @@ func (m *PushRequest) GetBuild() bool {
 }
 
 type CheckpointRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Ref                  string   `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
-	Exit                 bool     `protobuf:"varint,4,opt,name=exit,proto3" json:"exit,omitempty"`
+	ID   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref  string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live bool   `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
+	Exit bool   `protobuf:"varint,4,opt,name=exit,proto3" json:"exit,omitempty"`
+	// volumes includes the container's volumes and bind mounts as layers
+	Volumes              bool     `protobuf:"varint,5,opt,name=volumes,proto3" json:"volumes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -939,7 +1051,7 @@ func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
 func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckpointRequest) ProtoMessage()    {}
 func (*CheckpointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{20}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{21}
 }
 func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
@@ -987,6 +1099,13 @@ func (m *CheckpointRequest) GetExit() bool {
 	return false
 }
 
+func (m *CheckpointRequest) GetVolumes() bool {
+	if m != nil {
+		return m.Volumes
+	}
+	return false
+}
+
 type CheckpointResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -997,7 +1116,7 @@ func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
 func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckpointResponse) ProtoMessage()    {}
 func (*CheckpointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{21}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{22}
 }
 func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
@@ -1018,8 +1137,13 @@ func (m *CheckpointResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
 
 type RestoreRequest struct {
-	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	Ref  string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live bool   `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	// from is the node the container is migrated from, its addresses are taken over
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// bind_mounts are the sources of the bind mounts restored from the checkpoint,
+	// only volumes are restored when empty
+	BindMounts           []string `protobuf:"bytes,4,rep,name=bind_mounts,json=bindMounts" json:"bind_mounts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1029,7 +1153,7 @@ func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
 func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
 func (*RestoreRequest) ProtoMessage()    {}
 func (*RestoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{22}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{23}
 }
 func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
@@ -1063,6 +1187,20 @@ func (m *RestoreRequest) GetLive() bool {
 	return false
 }
 
+func (m *RestoreRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetBindMounts() []string {
+	if m != nil {
+		return m.BindMounts
+	}
+	return nil
+}
+
 type RestoreResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1073,7 +1211,7 @@ func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
 func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
 func (*RestoreResponse) ProtoMessage()    {}
 func (*RestoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{23}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{24}
 }
 func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
@@ -1094,12 +1232,22 @@ func (m *RestoreResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
 
 type MigrateRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Ref                  string   `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
-	Stop                 bool     `protobuf:"varint,4,opt,name=stop,proto3" json:"stop,omitempty"`
-	To                   string   `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
-	Delete               bool     `protobuf:"varint,6,opt,name=delete,proto3" json:"delete,omitempty"`
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ref pushes the checkpoint through a registry, it is streamed to the target when empty
+	Ref    string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live   bool   `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
+	Stop   bool   `protobuf:"varint,4,opt,name=stop,proto3" json:"stop,omitempty"`
+	To     string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Delete bool   `protobuf:"varint,6,opt,name=delete,proto3" json:"delete,omitempty"`
+	// precopy dumps the memory in rounds while the container runs and
+	// freezes it only for the final dump of the pages dirtied since
+	Precopy bool `protobuf:"varint,7,opt,name=precopy,proto3" json:"precopy,omitempty"`
+	// max_rounds limits the pre-dump rounds when the dirty pages do not converge
+	MaxRounds uint32 `protobuf:"varint,8,opt,name=max_rounds,json=maxRounds,proto3" json:"max_rounds,omitempty"`
+	// volumes migrates the container's volumes and bind mounts with it
+	Volumes bool `protobuf:"varint,9,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	// bind_mounts are the sources of the bind mounts restored on the target
+	BindMounts           []string `protobuf:"bytes,10,rep,name=bind_mounts,json=bindMounts" json:"bind_mounts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1109,7 +1257,7 @@ func (m *MigrateRequest) Reset()         { *m = MigrateRequest{} }
 func (m *MigrateRequest) String() string { return proto.CompactTextString(m) }
 func (*MigrateRequest) ProtoMessage()    {}
 func (*MigrateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{24}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{25}
 }
 func (m *MigrateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MigrateRequest.Unmarshal(m, b)
@@ -1171,17 +1319,46 @@ func (m *MigrateRequest) GetDelete() bool {
 	return false
 }
 
+func (m *MigrateRequest) GetPrecopy() bool {
+	if m != nil {
+		return m.Precopy
+	}
+	return false
+}
+
+func (m *MigrateRequest) GetMaxRounds() uint32 {
+	if m != nil {
+		return m.MaxRounds
+	}
+	return 0
+}
+
+func (m *MigrateRequest) GetVolumes() bool {
+	if m != nil {
+		return m.Volumes
+	}
+	return false
+}
+
+func (m *MigrateRequest) GetBindMounts() []string {
+	if m != nil {
+		return m.BindMounts
+	}
+	return nil
+}
+
 type MigrateResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Rounds               []*MigrateRound `protobuf:"bytes,1,rep,name=rounds" json:"rounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *MigrateResponse) Reset()         { *m = MigrateResponse{} }
 func (m *MigrateResponse) String() string { return proto.CompactTextString(m) }
 func (*MigrateResponse) ProtoMessage()    {}
 func (*MigrateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{25}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{26}
 }
 func (m *MigrateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MigrateResponse.Unmarshal(m, b)
@@ -1201,217 +1378,2141 @@ func (m *MigrateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MigrateResponse proto.InternalMessageInfo
 
-type Container struct {
-	ID                   string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Image                string              `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Network              string              `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
-	Process              *Process            `protobuf:"bytes,4,opt,name=process" json:"process,omitempty"`
-	Mounts               []*Mount            `protobuf:"bytes,5,rep,name=mounts" json:"mounts,omitempty"`
-	Resources            *Resources          `protobuf:"bytes,6,opt,name=resources" json:"resources,omitempty"`
-	Gpus                 *GPUs               `protobuf:"bytes,7,opt,name=gpus" json:"gpus,omitempty"`
-	Services             map[string]*Service `protobuf:"bytes,8,rep,name=services" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
-	Configs              map[string]*Config  `protobuf:"bytes,9,rep,name=configs" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
-	Readonly             bool                `protobuf:"varint,10,opt,name=readonly,proto3" json:"readonly,omitempty"`
-	Volumes              []*Volume           `protobuf:"bytes,11,rep,name=volumes" json:"volumes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *MigrateResponse) GetRounds() []*MigrateRound {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
 }
 
-func (m *Container) Reset()         { *m = Container{} }
-func (m *Container) String() string { return proto.CompactTextString(m) }
-func (*Container) ProtoMessage()    {}
-func (*Container) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{26}
+type MigrateRound struct {
+	Round uint32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// dirty is the size of the memory pages dumped in the round
+	Dirty int64 `protobuf:"varint,2,opt,name=dirty,proto3" json:"dirty,omitempty"`
+	// sent is the size of the images sent to the target
+	Sent                 int64         `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	Duration             time.Duration `protobuf:"varint,4,opt,name=duration,proto3,casttype=time.Duration" json:"duration,omitempty"`
+	Final                bool          `protobuf:"varint,5,opt,name=final,proto3" json:"final,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *Container) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Container.Unmarshal(m, b)
+
+func (m *MigrateRound) Reset()         { *m = MigrateRound{} }
+func (m *MigrateRound) String() string { return proto.CompactTextString(m) }
+func (*MigrateRound) ProtoMessage()    {}
+func (*MigrateRound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{27}
 }
-func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+func (m *MigrateRound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateRound.Unmarshal(m, b)
 }
-func (dst *Container) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Container.Merge(dst, src)
+func (m *MigrateRound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateRound.Marshal(b, m, deterministic)
 }
-func (m *Container) XXX_Size() int {
-	return xxx_messageInfo_Container.Size(m)
+func (dst *MigrateRound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateRound.Merge(dst, src)
 }
-func (m *Container) XXX_DiscardUnknown() {
-	xxx_messageInfo_Container.DiscardUnknown(m)
+func (m *MigrateRound) XXX_Size() int {
+	return xxx_messageInfo_MigrateRound.Size(m)
+}
+func (m *MigrateRound) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateRound.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Container proto.InternalMessageInfo
+var xxx_messageInfo_MigrateRound proto.InternalMessageInfo
 
-func (m *Container) GetID() string {
+func (m *MigrateRound) GetRound() uint32 {
 	if m != nil {
-		return m.ID
+		return m.Round
 	}
-	return ""
+	return 0
 }
 
-func (m *Container) GetImage() string {
+func (m *MigrateRound) GetDirty() int64 {
 	if m != nil {
-		return m.Image
+		return m.Dirty
 	}
-	return ""
+	return 0
 }
 
-func (m *Container) GetNetwork() string {
+func (m *MigrateRound) GetSent() int64 {
 	if m != nil {
-		return m.Network
+		return m.Sent
 	}
-	return ""
+	return 0
 }
 
-func (m *Container) GetProcess() *Process {
+func (m *MigrateRound) GetDuration() time.Duration {
 	if m != nil {
-		return m.Process
+		return m.Duration
 	}
-	return nil
+	return 0
 }
 
-func (m *Container) GetMounts() []*Mount {
+func (m *MigrateRound) GetFinal() bool {
 	if m != nil {
-		return m.Mounts
+		return m.Final
 	}
-	return nil
+	return false
 }
 
-func (m *Container) GetResources() *Resources {
+type PreCopyRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// path of the file relative to the migration
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// link is the target of a symlink
+	Link string `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"`
+	// restore the container once the final dump is received
+	Restore bool `protobuf:"varint,5,opt,name=restore,proto3" json:"restore,omitempty"`
+	// rounds is the number of pre-dump rounds before the final dump
+	Rounds uint32 `protobuf:"varint,6,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	// from is the node the container is migrated from
+	From string `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
+	// digest of the file, sent in a message after the file's data
+	Digest string `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`
+	// bind_mounts are the sources of the bind mounts restored on the target
+	BindMounts           []string `protobuf:"bytes,9,rep,name=bind_mounts,json=bindMounts" json:"bind_mounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PreCopyRequest) Reset()         { *m = PreCopyRequest{} }
+func (m *PreCopyRequest) String() string { return proto.CompactTextString(m) }
+func (*PreCopyRequest) ProtoMessage()    {}
+func (*PreCopyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{28}
+}
+func (m *PreCopyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreCopyRequest.Unmarshal(m, b)
+}
+func (m *PreCopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreCopyRequest.Marshal(b, m, deterministic)
+}
+func (dst *PreCopyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreCopyRequest.Merge(dst, src)
+}
+func (m *PreCopyRequest) XXX_Size() int {
+	return xxx_messageInfo_PreCopyRequest.Size(m)
+}
+func (m *PreCopyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreCopyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreCopyRequest proto.InternalMessageInfo
+
+func (m *PreCopyRequest) GetID() string {
 	if m != nil {
-		return m.Resources
+		return m.ID
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetGpus() *GPUs {
+func (m *PreCopyRequest) GetPath() string {
 	if m != nil {
-		return m.Gpus
+		return m.Path
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetServices() map[string]*Service {
+func (m *PreCopyRequest) GetData() []byte {
 	if m != nil {
-		return m.Services
+		return m.Data
 	}
 	return nil
 }
 
-func (m *Container) GetConfigs() map[string]*Config {
+func (m *PreCopyRequest) GetLink() string {
 	if m != nil {
-		return m.Configs
+		return m.Link
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetReadonly() bool {
+func (m *PreCopyRequest) GetRestore() bool {
 	if m != nil {
-		return m.Readonly
+		return m.Restore
 	}
 	return false
 }
 
-func (m *Container) GetVolumes() []*Volume {
+func (m *PreCopyRequest) GetRounds() uint32 {
 	if m != nil {
-		return m.Volumes
+		return m.Rounds
 	}
-	return nil
-}
-
-type Volume struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
-	Rw                   bool     `protobuf:"varint,3,opt,name=rw,proto3" json:"rw,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *Volume) Reset()         { *m = Volume{} }
-func (m *Volume) String() string { return proto.CompactTextString(m) }
-func (*Volume) ProtoMessage()    {}
-func (*Volume) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{27}
-}
-func (m *Volume) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Volume.Unmarshal(m, b)
-}
-func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
-}
-func (dst *Volume) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Volume.Merge(dst, src)
-}
-func (m *Volume) XXX_Size() int {
-	return xxx_messageInfo_Volume.Size(m)
-}
-func (m *Volume) XXX_DiscardUnknown() {
-	xxx_messageInfo_Volume.DiscardUnknown(m)
+	return 0
 }
 
-var xxx_messageInfo_Volume proto.InternalMessageInfo
-
-func (m *Volume) GetID() string {
+func (m *PreCopyRequest) GetFrom() string {
 	if m != nil {
-		return m.ID
+		return m.From
 	}
 	return ""
 }
 
-func (m *Volume) GetDestination() string {
+func (m *PreCopyRequest) GetDigest() string {
 	if m != nil {
-		return m.Destination
+		return m.Digest
 	}
 	return ""
 }
 
-func (m *Volume) GetRw() bool {
+func (m *PreCopyRequest) GetBindMounts() []string {
 	if m != nil {
-		return m.Rw
+		return m.BindMounts
 	}
-	return false
+	return nil
 }
 
-type Config struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
-	Signal               string   `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+type PreCopyResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Config) Reset()         { *m = Config{} }
-func (m *Config) String() string { return proto.CompactTextString(m) }
-func (*Config) ProtoMessage()    {}
-func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{28}
+func (m *PreCopyResponse) Reset()         { *m = PreCopyResponse{} }
+func (m *PreCopyResponse) String() string { return proto.CompactTextString(m) }
+func (*PreCopyResponse) ProtoMessage()    {}
+func (*PreCopyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{29}
 }
-func (m *Config) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Config.Unmarshal(m, b)
+func (m *PreCopyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreCopyResponse.Unmarshal(m, b)
 }
-func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+func (m *PreCopyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreCopyResponse.Marshal(b, m, deterministic)
 }
-func (dst *Config) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Config.Merge(dst, src)
+func (dst *PreCopyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreCopyResponse.Merge(dst, src)
 }
-func (m *Config) XXX_Size() int {
-	return xxx_messageInfo_Config.Size(m)
+func (m *PreCopyResponse) XXX_Size() int {
+	return xxx_messageInfo_PreCopyResponse.Size(m)
 }
-func (m *Config) XXX_DiscardUnknown() {
-	xxx_messageInfo_Config.DiscardUnknown(m)
+func (m *PreCopyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreCopyResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Config proto.InternalMessageInfo
+var xxx_messageInfo_PreCopyResponse proto.InternalMessageInfo
 
-func (m *Config) GetPath() string {
-	if m != nil {
-		return m.Path
-	}
-	return ""
+type TransferRequest struct {
+	// ref names the checkpoint on the target, sent with the first message
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// blob starts a blob of the checkpoint whose data follows in this and the next messages
+	Blob *Blob  `protobuf:"bytes,2,opt,name=blob" json:"blob,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// index is sent last with the data of the checkpoint's index
+	Index *Blob `protobuf:"bytes,4,opt,name=index" json:"index,omitempty"`
+	// restore the container from the checkpoint once it is received
+	Restore bool `protobuf:"varint,5,opt,name=restore,proto3" json:"restore,omitempty"`
+	Live    bool `protobuf:"varint,6,opt,name=live,proto3" json:"live,omitempty"`
+	// from is the node the container is migrated from
+	From string `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
+	// bind_mounts are the sources of the bind mounts restored on the target
+	BindMounts           []string `protobuf:"bytes,8,rep,name=bind_mounts,json=bindMounts" json:"bind_mounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
+func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()    {}
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{30}
+}
+func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
+}
+func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+}
+func (dst *TransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferRequest.Merge(dst, src)
+}
+func (m *TransferRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferRequest.Size(m)
+}
+func (m *TransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+
+func (m *TransferRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *TransferRequest) GetBlob() *Blob {
+	if m != nil {
+		return m.Blob
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetIndex() *Blob {
+	if m != nil {
+		return m.Index
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetRestore() bool {
+	if m != nil {
+		return m.Restore
+	}
+	return false
+}
+
+func (m *TransferRequest) GetLive() bool {
+	if m != nil {
+		return m.Live
+	}
+	return false
+}
+
+func (m *TransferRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *TransferRequest) GetBindMounts() []string {
+	if m != nil {
+		return m.BindMounts
+	}
+	return nil
+}
+
+type Blob struct {
+	MediaType            string            `protobuf:"bytes,1,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Digest               string            `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Size_                int64             `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Annotations          map[string]string `protobuf:"bytes,4,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Blob) Reset()         { *m = Blob{} }
+func (m *Blob) String() string { return proto.CompactTextString(m) }
+func (*Blob) ProtoMessage()    {}
+func (*Blob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{31}
+}
+func (m *Blob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Blob.Unmarshal(m, b)
+}
+func (m *Blob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Blob.Marshal(b, m, deterministic)
+}
+func (dst *Blob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Blob.Merge(dst, src)
+}
+func (m *Blob) XXX_Size() int {
+	return xxx_messageInfo_Blob.Size(m)
+}
+func (m *Blob) XXX_DiscardUnknown() {
+	xxx_messageInfo_Blob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Blob proto.InternalMessageInfo
+
+func (m *Blob) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+func (m *Blob) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Blob) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *Blob) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type TransferResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferResponse) Reset()         { *m = TransferResponse{} }
+func (m *TransferResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferResponse) ProtoMessage()    {}
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{32}
+}
+func (m *TransferResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferResponse.Unmarshal(m, b)
+}
+func (m *TransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferResponse.Marshal(b, m, deterministic)
+}
+func (dst *TransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferResponse.Merge(dst, src)
+}
+func (m *TransferResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferResponse.Size(m)
+}
+func (m *TransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferResponse proto.InternalMessageInfo
+
+type EstimateCheckpointRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Volumes              bool     `protobuf:"varint,2,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateCheckpointRequest) Reset()         { *m = EstimateCheckpointRequest{} }
+func (m *EstimateCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateCheckpointRequest) ProtoMessage()    {}
+func (*EstimateCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{33}
+}
+func (m *EstimateCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateCheckpointRequest.Unmarshal(m, b)
+}
+func (m *EstimateCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *EstimateCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateCheckpointRequest.Merge(dst, src)
+}
+func (m *EstimateCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimateCheckpointRequest.Size(m)
+}
+func (m *EstimateCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateCheckpointRequest proto.InternalMessageInfo
+
+func (m *EstimateCheckpointRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *EstimateCheckpointRequest) GetVolumes() bool {
+	if m != nil {
+		return m.Volumes
+	}
+	return false
+}
+
+type EstimateCheckpointResponse struct {
+	// rw is the size of the container's rw layer
+	RW int64 `protobuf:"varint,1,opt,name=rw,proto3" json:"rw,omitempty"`
+	// memory is the memory of a live checkpoint
+	Memory               int64             `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Volumes              []*VolumeEstimate `protobuf:"bytes,3,rep,name=volumes" json:"volumes,omitempty"`
+	Total                int64             `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EstimateCheckpointResponse) Reset()         { *m = EstimateCheckpointResponse{} }
+func (m *EstimateCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateCheckpointResponse) ProtoMessage()    {}
+func (*EstimateCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{34}
+}
+func (m *EstimateCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateCheckpointResponse.Unmarshal(m, b)
+}
+func (m *EstimateCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *EstimateCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateCheckpointResponse.Merge(dst, src)
+}
+func (m *EstimateCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateCheckpointResponse.Size(m)
+}
+func (m *EstimateCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateCheckpointResponse proto.InternalMessageInfo
+
+func (m *EstimateCheckpointResponse) GetRW() int64 {
+	if m != nil {
+		return m.RW
+	}
+	return 0
+}
+
+func (m *EstimateCheckpointResponse) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *EstimateCheckpointResponse) GetVolumes() []*VolumeEstimate {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *EstimateCheckpointResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type VolumeEstimate struct {
+	// name is the id of a volume or the source of a bind mount
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size_                int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeEstimate) Reset()         { *m = VolumeEstimate{} }
+func (m *VolumeEstimate) String() string { return proto.CompactTextString(m) }
+func (*VolumeEstimate) ProtoMessage()    {}
+func (*VolumeEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{35}
+}
+func (m *VolumeEstimate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeEstimate.Unmarshal(m, b)
+}
+func (m *VolumeEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeEstimate.Marshal(b, m, deterministic)
+}
+func (dst *VolumeEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeEstimate.Merge(dst, src)
+}
+func (m *VolumeEstimate) XXX_Size() int {
+	return xxx_messageInfo_VolumeEstimate.Size(m)
+}
+func (m *VolumeEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeEstimate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeEstimate proto.InternalMessageInfo
+
+func (m *VolumeEstimate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VolumeEstimate) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+type CheckpointsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointsRequest) Reset()         { *m = CheckpointsRequest{} }
+func (m *CheckpointsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointsRequest) ProtoMessage()    {}
+func (*CheckpointsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{36}
+}
+func (m *CheckpointsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointsRequest.Unmarshal(m, b)
+}
+func (m *CheckpointsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointsRequest.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointsRequest.Merge(dst, src)
+}
+func (m *CheckpointsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointsRequest.Size(m)
+}
+func (m *CheckpointsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointsRequest proto.InternalMessageInfo
+
+type CheckpointsResponse struct {
+	Checkpoints          []*CheckpointInfo `protobuf:"bytes,1,rep,name=checkpoints" json:"checkpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CheckpointsResponse) Reset()         { *m = CheckpointsResponse{} }
+func (m *CheckpointsResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointsResponse) ProtoMessage()    {}
+func (*CheckpointsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{37}
+}
+func (m *CheckpointsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointsResponse.Unmarshal(m, b)
+}
+func (m *CheckpointsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointsResponse.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointsResponse.Merge(dst, src)
+}
+func (m *CheckpointsResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointsResponse.Size(m)
+}
+func (m *CheckpointsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointsResponse proto.InternalMessageInfo
+
+func (m *CheckpointsResponse) GetCheckpoints() []*CheckpointInfo {
+	if m != nil {
+		return m.Checkpoints
+	}
+	return nil
+}
+
+type CheckpointInfo struct {
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// id of the checkpointed container
+	ID      string    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Image   string    `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Created time.Time `protobuf:"bytes,4,opt,name=created,stdtime" json:"created"`
+	// size of the checkpoint's index and blobs
+	Size_ int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// live checkpoints include the memory of the container
+	Live                 bool     `protobuf:"varint,6,opt,name=live,proto3" json:"live,omitempty"`
+	Volumes              bool     `protobuf:"varint,7,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointInfo) Reset()         { *m = CheckpointInfo{} }
+func (m *CheckpointInfo) String() string { return proto.CompactTextString(m) }
+func (*CheckpointInfo) ProtoMessage()    {}
+func (*CheckpointInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{38}
+}
+func (m *CheckpointInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointInfo.Unmarshal(m, b)
+}
+func (m *CheckpointInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointInfo.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointInfo.Merge(dst, src)
+}
+func (m *CheckpointInfo) XXX_Size() int {
+	return xxx_messageInfo_CheckpointInfo.Size(m)
+}
+func (m *CheckpointInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointInfo proto.InternalMessageInfo
+
+func (m *CheckpointInfo) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *CheckpointInfo) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *CheckpointInfo) GetLive() bool {
+	if m != nil {
+		return m.Live
+	}
+	return false
+}
+
+func (m *CheckpointInfo) GetVolumes() bool {
+	if m != nil {
+		return m.Volumes
+	}
+	return false
+}
+
+type InspectCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCheckpointRequest) Reset()         { *m = InspectCheckpointRequest{} }
+func (m *InspectCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCheckpointRequest) ProtoMessage()    {}
+func (*InspectCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{39}
+}
+func (m *InspectCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCheckpointRequest.Unmarshal(m, b)
+}
+func (m *InspectCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *InspectCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCheckpointRequest.Merge(dst, src)
+}
+func (m *InspectCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectCheckpointRequest.Size(m)
+}
+func (m *InspectCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCheckpointRequest proto.InternalMessageInfo
+
+func (m *InspectCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type InspectCheckpointResponse struct {
+	Checkpoint           *CheckpointInfo `protobuf:"bytes,1,opt,name=checkpoint" json:"checkpoint,omitempty"`
+	Config               *Container      `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+	Blobs                []*Blob         `protobuf:"bytes,3,rep,name=blobs" json:"blobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *InspectCheckpointResponse) Reset()         { *m = InspectCheckpointResponse{} }
+func (m *InspectCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectCheckpointResponse) ProtoMessage()    {}
+func (*InspectCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{40}
+}
+func (m *InspectCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCheckpointResponse.Unmarshal(m, b)
+}
+func (m *InspectCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *InspectCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCheckpointResponse.Merge(dst, src)
+}
+func (m *InspectCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectCheckpointResponse.Size(m)
+}
+func (m *InspectCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCheckpointResponse proto.InternalMessageInfo
+
+func (m *InspectCheckpointResponse) GetCheckpoint() *CheckpointInfo {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+func (m *InspectCheckpointResponse) GetConfig() *Container {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *InspectCheckpointResponse) GetBlobs() []*Blob {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+type DeleteCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCheckpointRequest) Reset()         { *m = DeleteCheckpointRequest{} }
+func (m *DeleteCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCheckpointRequest) ProtoMessage()    {}
+func (*DeleteCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{41}
+}
+func (m *DeleteCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCheckpointRequest.Unmarshal(m, b)
+}
+func (m *DeleteCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCheckpointRequest.Merge(dst, src)
+}
+func (m *DeleteCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCheckpointRequest.Size(m)
+}
+func (m *DeleteCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCheckpointRequest proto.InternalMessageInfo
+
+func (m *DeleteCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ExportCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCheckpointRequest) Reset()         { *m = ExportCheckpointRequest{} }
+func (m *ExportCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportCheckpointRequest) ProtoMessage()    {}
+func (*ExportCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{42}
+}
+func (m *ExportCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCheckpointRequest.Unmarshal(m, b)
+}
+func (m *ExportCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCheckpointRequest.Merge(dst, src)
+}
+func (m *ExportCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportCheckpointRequest.Size(m)
+}
+func (m *ExportCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCheckpointRequest proto.InternalMessageInfo
+
+func (m *ExportCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ExportCheckpointResponse struct {
+	// data of the oci image layout tar
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCheckpointResponse) Reset()         { *m = ExportCheckpointResponse{} }
+func (m *ExportCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportCheckpointResponse) ProtoMessage()    {}
+func (*ExportCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{43}
+}
+func (m *ExportCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCheckpointResponse.Unmarshal(m, b)
+}
+func (m *ExportCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExportCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCheckpointResponse.Merge(dst, src)
+}
+func (m *ExportCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportCheckpointResponse.Size(m)
+}
+func (m *ExportCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCheckpointResponse proto.InternalMessageInfo
+
+func (m *ExportCheckpointResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportCheckpointRequest struct {
+	// ref names the imported checkpoint instead of the name in the archive, sent with the first message
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// data of the oci image layout tar
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportCheckpointRequest) Reset()         { *m = ImportCheckpointRequest{} }
+func (m *ImportCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportCheckpointRequest) ProtoMessage()    {}
+func (*ImportCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{44}
+}
+func (m *ImportCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCheckpointRequest.Unmarshal(m, b)
+}
+func (m *ImportCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCheckpointRequest.Merge(dst, src)
+}
+func (m *ImportCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportCheckpointRequest.Size(m)
+}
+func (m *ImportCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCheckpointRequest proto.InternalMessageInfo
+
+func (m *ImportCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ImportCheckpointRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportCheckpointResponse struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportCheckpointResponse) Reset()         { *m = ImportCheckpointResponse{} }
+func (m *ImportCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportCheckpointResponse) ProtoMessage()    {}
+func (*ImportCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{45}
+}
+func (m *ImportCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCheckpointResponse.Unmarshal(m, b)
+}
+func (m *ImportCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCheckpointResponse.Merge(dst, src)
+}
+func (m *ImportCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportCheckpointResponse.Size(m)
+}
+func (m *ImportCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCheckpointResponse proto.InternalMessageInfo
+
+func (m *ImportCheckpointResponse) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type JobsRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobsRequest) Reset()         { *m = JobsRequest{} }
+func (m *JobsRequest) String() string { return proto.CompactTextString(m) }
+func (*JobsRequest) ProtoMessage()    {}
+func (*JobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{46}
+}
+func (m *JobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobsRequest.Unmarshal(m, b)
+}
+func (m *JobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobsRequest.Marshal(b, m, deterministic)
+}
+func (dst *JobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobsRequest.Merge(dst, src)
+}
+func (m *JobsRequest) XXX_Size() int {
+	return xxx_messageInfo_JobsRequest.Size(m)
+}
+func (m *JobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobsRequest proto.InternalMessageInfo
+
+func (m *JobsRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type JobsResponse struct {
+	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobsResponse) Reset()         { *m = JobsResponse{} }
+func (m *JobsResponse) String() string { return proto.CompactTextString(m) }
+func (*JobsResponse) ProtoMessage()    {}
+func (*JobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{47}
+}
+func (m *JobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobsResponse.Unmarshal(m, b)
+}
+func (m *JobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobsResponse.Marshal(b, m, deterministic)
+}
+func (dst *JobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobsResponse.Merge(dst, src)
+}
+func (m *JobsResponse) XXX_Size() int {
+	return xxx_messageInfo_JobsResponse.Size(m)
+}
+func (m *JobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobsResponse proto.InternalMessageInfo
+
+func (m *JobsResponse) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type Job struct {
+	ID                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Schedule             string    `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Status               string    `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Runs                 []*JobRun `protobuf:"bytes,4,rep,name=runs" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{48}
+}
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (dst *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(dst, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Job) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *Job) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Job) GetRuns() []*JobRun {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type JobRun struct {
+	Started              time.Time `protobuf:"bytes,1,opt,name=started,stdtime" json:"started"`
+	Ended                time.Time `protobuf:"bytes,2,opt,name=ended,stdtime" json:"ended"`
+	ExitCode             int32     `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *JobRun) Reset()         { *m = JobRun{} }
+func (m *JobRun) String() string { return proto.CompactTextString(m) }
+func (*JobRun) ProtoMessage()    {}
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{49}
+}
+func (m *JobRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobRun.Unmarshal(m, b)
+}
+func (m *JobRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobRun.Marshal(b, m, deterministic)
+}
+func (dst *JobRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRun.Merge(dst, src)
+}
+func (m *JobRun) XXX_Size() int {
+	return xxx_messageInfo_JobRun.Size(m)
+}
+func (m *JobRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRun proto.InternalMessageInfo
+
+func (m *JobRun) GetStarted() time.Time {
+	if m != nil {
+		return m.Started
+	}
+	return time.Time{}
+}
+
+func (m *JobRun) GetEnded() time.Time {
+	if m != nil {
+		return m.Ended
+	}
+	return time.Time{}
+}
+
+func (m *JobRun) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type JobHistory struct {
+	Runs                 []*JobRun `protobuf:"bytes,1,rep,name=runs" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *JobHistory) Reset()         { *m = JobHistory{} }
+func (m *JobHistory) String() string { return proto.CompactTextString(m) }
+func (*JobHistory) ProtoMessage()    {}
+func (*JobHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{50}
+}
+func (m *JobHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobHistory.Unmarshal(m, b)
+}
+func (m *JobHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobHistory.Marshal(b, m, deterministic)
+}
+func (dst *JobHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobHistory.Merge(dst, src)
+}
+func (m *JobHistory) XXX_Size() int {
+	return xxx_messageInfo_JobHistory.Size(m)
+}
+func (m *JobHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobHistory proto.InternalMessageInfo
+
+func (m *JobHistory) GetRuns() []*JobRun {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type RunJobRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunJobRequest) Reset()         { *m = RunJobRequest{} }
+func (m *RunJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RunJobRequest) ProtoMessage()    {}
+func (*RunJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{51}
+}
+func (m *RunJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunJobRequest.Unmarshal(m, b)
+}
+func (m *RunJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunJobRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunJobRequest.Merge(dst, src)
+}
+func (m *RunJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RunJobRequest.Size(m)
+}
+func (m *RunJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunJobRequest proto.InternalMessageInfo
+
+func (m *RunJobRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type Container struct {
+	ID          string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Image       string              `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Network     string              `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	Process     *Process            `protobuf:"bytes,4,opt,name=process" json:"process,omitempty"`
+	Mounts      []*Mount            `protobuf:"bytes,5,rep,name=mounts" json:"mounts,omitempty"`
+	Resources   *Resources          `protobuf:"bytes,6,opt,name=resources" json:"resources,omitempty"`
+	Gpus        *GPUs               `protobuf:"bytes,7,opt,name=gpus" json:"gpus,omitempty"`
+	Services    map[string]*Service `protobuf:"bytes,8,rep,name=services" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Configs     map[string]*Config  `protobuf:"bytes,9,rep,name=configs" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Readonly    bool                `protobuf:"varint,10,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	Volumes     []*Volume           `protobuf:"bytes,11,rep,name=volumes" json:"volumes,omitempty"`
+	Userns      *UserNamespace      `protobuf:"bytes,12,opt,name=userns" json:"userns,omitempty"`
+	Security    *Security           `protobuf:"bytes,13,opt,name=security" json:"security,omitempty"`
+	Restart     *RestartPolicy      `protobuf:"bytes,14,opt,name=restart" json:"restart,omitempty"`
+	Type        string              `protobuf:"bytes,15,opt,name=type,proto3" json:"type,omitempty"`
+	Schedule    string              `protobuf:"bytes,16,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Init        []*InitContainer    `protobuf:"bytes,17,rep,name=init" json:"init,omitempty"`
+	StopSignal  string              `protobuf:"bytes,18,opt,name=stop_signal,json=stopSignal,proto3" json:"stop_signal,omitempty"`
+	StopTimeout int64               `protobuf:"varint,19,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"`
+	PreStop     []*Hook             `protobuf:"bytes,20,rep,name=pre_stop,json=preStop" json:"pre_stop,omitempty"`
+	Sidecars    []*Sidecar          `protobuf:"bytes,21,rep,name=sidecars" json:"sidecars,omitempty"`
+	Ports       []*PortMapping      `protobuf:"bytes,22,rep,name=ports" json:"ports,omitempty"`
+	Networks    []string            `protobuf:"bytes,23,rep,name=networks" json:"networks,omitempty"`
+	IP          string              `protobuf:"bytes,24,opt,name=ip,proto3" json:"ip,omitempty"`
+	Allow       *Policy             `protobuf:"bytes,25,opt,name=allow" json:"allow,omitempty"`
+	// rates in bits per second
+	IngressRate          uint64   `protobuf:"varint,26,opt,name=ingress_rate,json=ingressRate,proto3" json:"ingress_rate,omitempty"`
+	EgressRate           uint64   `protobuf:"varint,27,opt,name=egress_rate,json=egressRate,proto3" json:"egress_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Container) Reset()         { *m = Container{} }
+func (m *Container) String() string { return proto.CompactTextString(m) }
+func (*Container) ProtoMessage()    {}
+func (*Container) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{52}
+}
+func (m *Container) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Container.Unmarshal(m, b)
+}
+func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+}
+func (dst *Container) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Container.Merge(dst, src)
+}
+func (m *Container) XXX_Size() int {
+	return xxx_messageInfo_Container.Size(m)
+}
+func (m *Container) XXX_DiscardUnknown() {
+	xxx_messageInfo_Container.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Container proto.InternalMessageInfo
+
+func (m *Container) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Container) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Container) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *Container) GetProcess() *Process {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
+func (m *Container) GetMounts() []*Mount {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
+func (m *Container) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Container) GetGpus() *GPUs {
+	if m != nil {
+		return m.Gpus
+	}
+	return nil
+}
+
+func (m *Container) GetServices() map[string]*Service {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *Container) GetConfigs() map[string]*Config {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+func (m *Container) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
+	}
+	return false
+}
+
+func (m *Container) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *Container) GetUserns() *UserNamespace {
+	if m != nil {
+		return m.Userns
+	}
+	return nil
+}
+
+func (m *Container) GetSecurity() *Security {
+	if m != nil {
+		return m.Security
+	}
+	return nil
+}
+
+func (m *Container) GetRestart() *RestartPolicy {
+	if m != nil {
+		return m.Restart
+	}
+	return nil
+}
+
+func (m *Container) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Container) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *Container) GetInit() []*InitContainer {
+	if m != nil {
+		return m.Init
+	}
+	return nil
+}
+
+func (m *Container) GetStopSignal() string {
+	if m != nil {
+		return m.StopSignal
+	}
+	return ""
+}
+
+func (m *Container) GetStopTimeout() int64 {
+	if m != nil {
+		return m.StopTimeout
+	}
+	return 0
+}
+
+func (m *Container) GetPreStop() []*Hook {
+	if m != nil {
+		return m.PreStop
+	}
+	return nil
+}
+
+func (m *Container) GetSidecars() []*Sidecar {
+	if m != nil {
+		return m.Sidecars
+	}
+	return nil
+}
+
+func (m *Container) GetPorts() []*PortMapping {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *Container) GetNetworks() []string {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
+}
+
+func (m *Container) GetIP() string {
+	if m != nil {
+		return m.IP
+	}
+	return ""
+}
+
+func (m *Container) GetAllow() *Policy {
+	if m != nil {
+		return m.Allow
+	}
+	return nil
+}
+
+func (m *Container) GetIngressRate() uint64 {
+	if m != nil {
+		return m.IngressRate
+	}
+	return 0
+}
+
+func (m *Container) GetEgressRate() uint64 {
+	if m != nil {
+		return m.EgressRate
+	}
+	return 0
+}
+
+type Policy struct {
+	Ingress              []*PolicyRule `protobuf:"bytes,1,rep,name=ingress" json:"ingress,omitempty"`
+	Egress               []*PolicyRule `protobuf:"bytes,2,rep,name=egress" json:"egress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Policy) Reset()         { *m = Policy{} }
+func (m *Policy) String() string { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()    {}
+func (*Policy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{53}
+}
+func (m *Policy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Policy.Unmarshal(m, b)
+}
+func (m *Policy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Policy.Marshal(b, m, deterministic)
+}
+func (dst *Policy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Policy.Merge(dst, src)
+}
+func (m *Policy) XXX_Size() int {
+	return xxx_messageInfo_Policy.Size(m)
+}
+func (m *Policy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Policy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Policy proto.InternalMessageInfo
+
+func (m *Policy) GetIngress() []*PolicyRule {
+	if m != nil {
+		return m.Ingress
+	}
+	return nil
+}
+
+func (m *Policy) GetEgress() []*PolicyRule {
+	if m != nil {
+		return m.Egress
+	}
+	return nil
+}
+
+type PolicyRule struct {
+	Services             []string `protobuf:"bytes,1,rep,name=services" json:"services,omitempty"`
+	CIDRs                []string `protobuf:"bytes,2,rep,name=cidrs" json:"cidrs,omitempty"`
+	Ports                []uint32 `protobuf:"varint,3,rep,packed,name=ports" json:"ports,omitempty"`
+	Protocol             string   `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolicyRule) Reset()         { *m = PolicyRule{} }
+func (m *PolicyRule) String() string { return proto.CompactTextString(m) }
+func (*PolicyRule) ProtoMessage()    {}
+func (*PolicyRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{54}
+}
+func (m *PolicyRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyRule.Unmarshal(m, b)
+}
+func (m *PolicyRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyRule.Marshal(b, m, deterministic)
+}
+func (dst *PolicyRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyRule.Merge(dst, src)
+}
+func (m *PolicyRule) XXX_Size() int {
+	return xxx_messageInfo_PolicyRule.Size(m)
+}
+func (m *PolicyRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyRule proto.InternalMessageInfo
+
+func (m *PolicyRule) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *PolicyRule) GetCIDRs() []string {
+	if m != nil {
+		return m.CIDRs
+	}
+	return nil
+}
+
+func (m *PolicyRule) GetPorts() []uint32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *PolicyRule) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+type PortMapping struct {
+	HostPort             uint32   `protobuf:"varint,1,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`
+	ContainerPort        uint32   `protobuf:"varint,2,opt,name=container_port,json=containerPort,proto3" json:"container_port,omitempty"`
+	Protocol             string   `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	HostIP               string   `protobuf:"bytes,4,opt,name=host_ip,json=hostIp,proto3" json:"host_ip,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortMapping) Reset()         { *m = PortMapping{} }
+func (m *PortMapping) String() string { return proto.CompactTextString(m) }
+func (*PortMapping) ProtoMessage()    {}
+func (*PortMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{55}
+}
+func (m *PortMapping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortMapping.Unmarshal(m, b)
+}
+func (m *PortMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortMapping.Marshal(b, m, deterministic)
+}
+func (dst *PortMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortMapping.Merge(dst, src)
+}
+func (m *PortMapping) XXX_Size() int {
+	return xxx_messageInfo_PortMapping.Size(m)
+}
+func (m *PortMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortMapping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortMapping proto.InternalMessageInfo
+
+func (m *PortMapping) GetHostPort() uint32 {
+	if m != nil {
+		return m.HostPort
+	}
+	return 0
+}
+
+func (m *PortMapping) GetContainerPort() uint32 {
+	if m != nil {
+		return m.ContainerPort
+	}
+	return 0
+}
+
+func (m *PortMapping) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *PortMapping) GetHostIP() string {
+	if m != nil {
+		return m.HostIP
+	}
+	return ""
+}
+
+type Sidecar struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image                string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Args                 []string `protobuf:"bytes,3,rep,name=args" json:"args,omitempty"`
+	Env                  []string `protobuf:"bytes,4,rep,name=env" json:"env,omitempty"`
+	Volumes              bool     `protobuf:"varint,5,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sidecar) Reset()         { *m = Sidecar{} }
+func (m *Sidecar) String() string { return proto.CompactTextString(m) }
+func (*Sidecar) ProtoMessage()    {}
+func (*Sidecar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{56}
+}
+func (m *Sidecar) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sidecar.Unmarshal(m, b)
+}
+func (m *Sidecar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sidecar.Marshal(b, m, deterministic)
+}
+func (dst *Sidecar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sidecar.Merge(dst, src)
+}
+func (m *Sidecar) XXX_Size() int {
+	return xxx_messageInfo_Sidecar.Size(m)
+}
+func (m *Sidecar) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sidecar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sidecar proto.InternalMessageInfo
+
+func (m *Sidecar) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Sidecar) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Sidecar) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Sidecar) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Sidecar) GetVolumes() bool {
+	if m != nil {
+		return m.Volumes
+	}
+	return false
+}
+
+type Hook struct {
+	Args                 []string `protobuf:"bytes,1,rep,name=args" json:"args,omitempty"`
+	Timeout              int64    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hook) Reset()         { *m = Hook{} }
+func (m *Hook) String() string { return proto.CompactTextString(m) }
+func (*Hook) ProtoMessage()    {}
+func (*Hook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{57}
+}
+func (m *Hook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hook.Unmarshal(m, b)
+}
+func (m *Hook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hook.Marshal(b, m, deterministic)
+}
+func (dst *Hook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hook.Merge(dst, src)
+}
+func (m *Hook) XXX_Size() int {
+	return xxx_messageInfo_Hook.Size(m)
+}
+func (m *Hook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hook proto.InternalMessageInfo
+
+func (m *Hook) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Hook) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type InitContainer struct {
+	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Args                 []string `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InitContainer) Reset()         { *m = InitContainer{} }
+func (m *InitContainer) String() string { return proto.CompactTextString(m) }
+func (*InitContainer) ProtoMessage()    {}
+func (*InitContainer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{58}
+}
+func (m *InitContainer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitContainer.Unmarshal(m, b)
+}
+func (m *InitContainer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitContainer.Marshal(b, m, deterministic)
+}
+func (dst *InitContainer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitContainer.Merge(dst, src)
+}
+func (m *InitContainer) XXX_Size() int {
+	return xxx_messageInfo_InitContainer.Size(m)
+}
+func (m *InitContainer) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitContainer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitContainer proto.InternalMessageInfo
+
+func (m *InitContainer) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *InitContainer) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type RestartPolicy struct {
+	Policy               string   `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	MaxRetries           int64    `protobuf:"varint,2,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	Delay                int64    `protobuf:"varint,3,opt,name=delay,proto3" json:"delay,omitempty"`
+	MaxDelay             int64    `protobuf:"varint,4,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestartPolicy) Reset()         { *m = RestartPolicy{} }
+func (m *RestartPolicy) String() string { return proto.CompactTextString(m) }
+func (*RestartPolicy) ProtoMessage()    {}
+func (*RestartPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{59}
+}
+func (m *RestartPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartPolicy.Unmarshal(m, b)
+}
+func (m *RestartPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartPolicy.Marshal(b, m, deterministic)
+}
+func (dst *RestartPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartPolicy.Merge(dst, src)
+}
+func (m *RestartPolicy) XXX_Size() int {
+	return xxx_messageInfo_RestartPolicy.Size(m)
+}
+func (m *RestartPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartPolicy proto.InternalMessageInfo
+
+func (m *RestartPolicy) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *RestartPolicy) GetMaxRetries() int64 {
+	if m != nil {
+		return m.MaxRetries
+	}
+	return 0
+}
+
+func (m *RestartPolicy) GetDelay() int64 {
+	if m != nil {
+		return m.Delay
+	}
+	return 0
+}
+
+func (m *RestartPolicy) GetMaxDelay() int64 {
+	if m != nil {
+		return m.MaxDelay
+	}
+	return 0
+}
+
+type Security struct {
+	Seccomp              string   `protobuf:"bytes,1,opt,name=seccomp,proto3" json:"seccomp,omitempty"`
+	SeccompProfile       []byte   `protobuf:"bytes,2,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	Apparmor             string   `protobuf:"bytes,3,opt,name=apparmor,proto3" json:"apparmor,omitempty"`
+	ApparmorProfile      []byte   `protobuf:"bytes,4,opt,name=apparmor_profile,json=apparmorProfile,proto3" json:"apparmor_profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Security) Reset()         { *m = Security{} }
+func (m *Security) String() string { return proto.CompactTextString(m) }
+func (*Security) ProtoMessage()    {}
+func (*Security) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{60}
+}
+func (m *Security) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Security.Unmarshal(m, b)
+}
+func (m *Security) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Security.Marshal(b, m, deterministic)
+}
+func (dst *Security) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Security.Merge(dst, src)
+}
+func (m *Security) XXX_Size() int {
+	return xxx_messageInfo_Security.Size(m)
+}
+func (m *Security) XXX_DiscardUnknown() {
+	xxx_messageInfo_Security.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Security proto.InternalMessageInfo
+
+func (m *Security) GetSeccomp() string {
+	if m != nil {
+		return m.Seccomp
+	}
+	return ""
+}
+
+func (m *Security) GetSeccompProfile() []byte {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return nil
+}
+
+func (m *Security) GetApparmor() string {
+	if m != nil {
+		return m.Apparmor
+	}
+	return ""
+}
+
+func (m *Security) GetApparmorProfile() []byte {
+	if m != nil {
+		return m.ApparmorProfile
+	}
+	return nil
+}
+
+type UserNamespace struct {
+	Uid                  uint32   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  uint32   `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
+	Size_                uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserNamespace) Reset()         { *m = UserNamespace{} }
+func (m *UserNamespace) String() string { return proto.CompactTextString(m) }
+func (*UserNamespace) ProtoMessage()    {}
+func (*UserNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{61}
+}
+func (m *UserNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserNamespace.Unmarshal(m, b)
+}
+func (m *UserNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserNamespace.Marshal(b, m, deterministic)
+}
+func (dst *UserNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserNamespace.Merge(dst, src)
+}
+func (m *UserNamespace) XXX_Size() int {
+	return xxx_messageInfo_UserNamespace.Size(m)
+}
+func (m *UserNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserNamespace proto.InternalMessageInfo
+
+func (m *UserNamespace) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetSize_() uint32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+type Volume struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Rw                   bool     `protobuf:"varint,3,opt,name=rw,proto3" json:"rw,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{62}
+}
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Volume.Unmarshal(m, b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+}
+func (dst *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(dst, src)
+}
+func (m *Volume) XXX_Size() int {
+	return xxx_messageInfo_Volume.Size(m)
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Volume) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Volume) GetRw() bool {
+	if m != nil {
+		return m.Rw
+	}
+	return false
+}
+
+type Config struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Signal               string   `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`
+	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{63}
+}
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (dst *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(dst, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
 }
 
 func (m *Config) GetSource() string {
@@ -1440,6 +3541,7 @@ type Service struct {
 	Labels               []string     `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty"`
 	Url                  string       `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
 	Check                *HealthCheck `protobuf:"bytes,4,opt,name=check" json:"check,omitempty"`
+	Ingress              *Ingress     `protobuf:"bytes,5,opt,name=ingress" json:"ingress,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1449,7 +3551,7 @@ func (m *Service) Reset()         { *m = Service{} }
 func (m *Service) String() string { return proto.CompactTextString(m) }
 func (*Service) ProtoMessage()    {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{29}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{64}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Service.Unmarshal(m, b)
@@ -1497,6 +3599,59 @@ func (m *Service) GetCheck() *HealthCheck {
 	return nil
 }
 
+func (m *Service) GetIngress() *Ingress {
+	if m != nil {
+		return m.Ingress
+	}
+	return nil
+}
+
+type Ingress struct {
+	Hosts                []string `protobuf:"bytes,1,rep,name=hosts" json:"hosts,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ingress) Reset()         { *m = Ingress{} }
+func (m *Ingress) String() string { return proto.CompactTextString(m) }
+func (*Ingress) ProtoMessage()    {}
+func (*Ingress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{65}
+}
+func (m *Ingress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ingress.Unmarshal(m, b)
+}
+func (m *Ingress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ingress.Marshal(b, m, deterministic)
+}
+func (dst *Ingress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ingress.Merge(dst, src)
+}
+func (m *Ingress) XXX_Size() int {
+	return xxx_messageInfo_Ingress.Size(m)
+}
+func (m *Ingress) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ingress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ingress proto.InternalMessageInfo
+
+func (m *Ingress) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *Ingress) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type HealthCheck struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Interval             int64    `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
@@ -1511,7 +3666,7 @@ func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
 func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
 func (*HealthCheck) ProtoMessage()    {}
 func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{30}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{66}
 }
 func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HealthCheck.Unmarshal(m, b)
@@ -1571,7 +3726,7 @@ func (m *GPUs) Reset()         { *m = GPUs{} }
 func (m *GPUs) String() string { return proto.CompactTextString(m) }
 func (*GPUs) ProtoMessage()    {}
 func (*GPUs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{31}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{67}
 }
 func (m *GPUs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GPUs.Unmarshal(m, b)
@@ -1606,20 +3761,32 @@ func (m *GPUs) GetCapabilities() []string {
 }
 
 type Resources struct {
-	Cpus                 float64  `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
-	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
-	NoFile               uint64   `protobuf:"varint,4,opt,name=no_file,json=noFile,proto3" json:"no_file,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Cpus                 float64           `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	Memory               int64             `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Score                int64             `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	NoFile               uint64            `protobuf:"varint,4,opt,name=no_file,json=noFile,proto3" json:"no_file,omitempty"`
+	MemoryReservation    int64             `protobuf:"varint,5,opt,name=memory_reservation,json=memoryReservation,proto3" json:"memory_reservation,omitempty"`
+	MemorySwap           int64             `protobuf:"varint,6,opt,name=memory_swap,json=memorySwap,proto3" json:"memory_swap,omitempty"`
+	CpusetCpus           string            `protobuf:"bytes,7,opt,name=cpuset_cpus,json=cpusetCpus,proto3" json:"cpuset_cpus,omitempty"`
+	CpusetMems           string            `protobuf:"bytes,8,opt,name=cpuset_mems,json=cpusetMems,proto3" json:"cpuset_mems,omitempty"`
+	Pids                 int64             `protobuf:"varint,9,opt,name=pids,proto3" json:"pids,omitempty"`
+	BlkioWeight          uint32            `protobuf:"varint,10,opt,name=blkio_weight,json=blkioWeight,proto3" json:"blkio_weight,omitempty"`
+	BlkioWeightDevices   []*WeightDevice   `protobuf:"bytes,11,rep,name=blkio_weight_devices,json=blkioWeightDevices" json:"blkio_weight_devices,omitempty"`
+	DeviceReadBps        []*ThrottleDevice `protobuf:"bytes,12,rep,name=device_read_bps,json=deviceReadBps" json:"device_read_bps,omitempty"`
+	DeviceWriteBps       []*ThrottleDevice `protobuf:"bytes,13,rep,name=device_write_bps,json=deviceWriteBps" json:"device_write_bps,omitempty"`
+	DeviceReadIops       []*ThrottleDevice `protobuf:"bytes,14,rep,name=device_read_iops,json=deviceReadIops" json:"device_read_iops,omitempty"`
+	DeviceWriteIops      []*ThrottleDevice `protobuf:"bytes,15,rep,name=device_write_iops,json=deviceWriteIops" json:"device_write_iops,omitempty"`
+	Rlimits              []*Rlimit         `protobuf:"bytes,16,rep,name=rlimits" json:"rlimits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{32}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{68}
 }
 func (m *Resources) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Resources.Unmarshal(m, b)
@@ -1650,19 +3817,249 @@ func (m *Resources) GetMemory() int64 {
 	if m != nil {
 		return m.Memory
 	}
-	return 0
+	return 0
+}
+
+func (m *Resources) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Resources) GetNoFile() uint64 {
+	if m != nil {
+		return m.NoFile
+	}
+	return 0
+}
+
+func (m *Resources) GetMemoryReservation() int64 {
+	if m != nil {
+		return m.MemoryReservation
+	}
+	return 0
+}
+
+func (m *Resources) GetMemorySwap() int64 {
+	if m != nil {
+		return m.MemorySwap
+	}
+	return 0
+}
+
+func (m *Resources) GetCpusetCpus() string {
+	if m != nil {
+		return m.CpusetCpus
+	}
+	return ""
+}
+
+func (m *Resources) GetCpusetMems() string {
+	if m != nil {
+		return m.CpusetMems
+	}
+	return ""
+}
+
+func (m *Resources) GetPids() int64 {
+	if m != nil {
+		return m.Pids
+	}
+	return 0
+}
+
+func (m *Resources) GetBlkioWeight() uint32 {
+	if m != nil {
+		return m.BlkioWeight
+	}
+	return 0
+}
+
+func (m *Resources) GetBlkioWeightDevices() []*WeightDevice {
+	if m != nil {
+		return m.BlkioWeightDevices
+	}
+	return nil
+}
+
+func (m *Resources) GetDeviceReadBps() []*ThrottleDevice {
+	if m != nil {
+		return m.DeviceReadBps
+	}
+	return nil
+}
+
+func (m *Resources) GetDeviceWriteBps() []*ThrottleDevice {
+	if m != nil {
+		return m.DeviceWriteBps
+	}
+	return nil
+}
+
+func (m *Resources) GetDeviceReadIops() []*ThrottleDevice {
+	if m != nil {
+		return m.DeviceReadIops
+	}
+	return nil
+}
+
+func (m *Resources) GetDeviceWriteIops() []*ThrottleDevice {
+	if m != nil {
+		return m.DeviceWriteIops
+	}
+	return nil
+}
+
+func (m *Resources) GetRlimits() []*Rlimit {
+	if m != nil {
+		return m.Rlimits
+	}
+	return nil
+}
+
+type WeightDevice struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Weight               uint32   `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WeightDevice) Reset()         { *m = WeightDevice{} }
+func (m *WeightDevice) String() string { return proto.CompactTextString(m) }
+func (*WeightDevice) ProtoMessage()    {}
+func (*WeightDevice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{69}
+}
+func (m *WeightDevice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WeightDevice.Unmarshal(m, b)
+}
+func (m *WeightDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WeightDevice.Marshal(b, m, deterministic)
+}
+func (dst *WeightDevice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WeightDevice.Merge(dst, src)
+}
+func (m *WeightDevice) XXX_Size() int {
+	return xxx_messageInfo_WeightDevice.Size(m)
+}
+func (m *WeightDevice) XXX_DiscardUnknown() {
+	xxx_messageInfo_WeightDevice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WeightDevice proto.InternalMessageInfo
+
+func (m *WeightDevice) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WeightDevice) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+type ThrottleDevice struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Rate                 uint64   `protobuf:"varint,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThrottleDevice) Reset()         { *m = ThrottleDevice{} }
+func (m *ThrottleDevice) String() string { return proto.CompactTextString(m) }
+func (*ThrottleDevice) ProtoMessage()    {}
+func (*ThrottleDevice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{70}
+}
+func (m *ThrottleDevice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottleDevice.Unmarshal(m, b)
+}
+func (m *ThrottleDevice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottleDevice.Marshal(b, m, deterministic)
+}
+func (dst *ThrottleDevice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottleDevice.Merge(dst, src)
+}
+func (m *ThrottleDevice) XXX_Size() int {
+	return xxx_messageInfo_ThrottleDevice.Size(m)
+}
+func (m *ThrottleDevice) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottleDevice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottleDevice proto.InternalMessageInfo
+
+func (m *ThrottleDevice) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ThrottleDevice) GetRate() uint64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+type Rlimit struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Hard                 uint64   `protobuf:"varint,2,opt,name=hard,proto3" json:"hard,omitempty"`
+	Soft                 uint64   `protobuf:"varint,3,opt,name=soft,proto3" json:"soft,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rlimit) Reset()         { *m = Rlimit{} }
+func (m *Rlimit) String() string { return proto.CompactTextString(m) }
+func (*Rlimit) ProtoMessage()    {}
+func (*Rlimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_86c583f9ab470e68, []int{71}
+}
+func (m *Rlimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rlimit.Unmarshal(m, b)
+}
+func (m *Rlimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rlimit.Marshal(b, m, deterministic)
+}
+func (dst *Rlimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rlimit.Merge(dst, src)
+}
+func (m *Rlimit) XXX_Size() int {
+	return xxx_messageInfo_Rlimit.Size(m)
+}
+func (m *Rlimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rlimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rlimit proto.InternalMessageInfo
+
+func (m *Rlimit) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
 }
 
-func (m *Resources) GetScore() int64 {
+func (m *Rlimit) GetHard() uint64 {
 	if m != nil {
-		return m.Score
+		return m.Hard
 	}
 	return 0
 }
 
-func (m *Resources) GetNoFile() uint64 {
+func (m *Rlimit) GetSoft() uint64 {
 	if m != nil {
-		return m.NoFile
+		return m.Soft
 	}
 	return 0
 }
@@ -1681,7 +4078,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{33}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{72}
 }
 func (m *Mount) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Mount.Unmarshal(m, b)
@@ -1743,7 +4140,7 @@ func (m *Process) Reset()         { *m = Process{} }
 func (m *Process) String() string { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()    {}
 func (*Process) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{34}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{73}
 }
 func (m *Process) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Process.Unmarshal(m, b)
@@ -1803,7 +4200,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_0c2fc37a348abd77, []int{35}
+	return fileDescriptor_boss_86c583f9ab470e68, []int{74}
 }
 func (m *User) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_User.Unmarshal(m, b)
@@ -1850,6 +4247,8 @@ func init() {
 	proto.RegisterType((*Node)(nil), "io.boss.v1.Node")
 	proto.RegisterMapType((map[string]string)(nil), "io.boss.v1.Node.LabelsEntry")
 	proto.RegisterType((*ContainerInfo)(nil), "io.boss.v1.ContainerInfo")
+	proto.RegisterMapType((map[string]string)(nil), "io.boss.v1.ContainerInfo.NetworksEntry")
+	proto.RegisterType((*SidecarInfo)(nil), "io.boss.v1.SidecarInfo")
 	proto.RegisterType((*Snapshot)(nil), "io.boss.v1.Snapshot")
 	proto.RegisterType((*RollbackRequest)(nil), "io.boss.v1.RollbackRequest")
 	proto.RegisterType((*RollbackResponse)(nil), "io.boss.v1.RollbackResponse")
@@ -1865,15 +4264,54 @@ func init() {
 	proto.RegisterType((*RestoreResponse)(nil), "io.boss.v1.RestoreResponse")
 	proto.RegisterType((*MigrateRequest)(nil), "io.boss.v1.MigrateRequest")
 	proto.RegisterType((*MigrateResponse)(nil), "io.boss.v1.MigrateResponse")
+	proto.RegisterType((*MigrateRound)(nil), "io.boss.v1.MigrateRound")
+	proto.RegisterType((*PreCopyRequest)(nil), "io.boss.v1.PreCopyRequest")
+	proto.RegisterType((*PreCopyResponse)(nil), "io.boss.v1.PreCopyResponse")
+	proto.RegisterType((*TransferRequest)(nil), "io.boss.v1.TransferRequest")
+	proto.RegisterType((*Blob)(nil), "io.boss.v1.Blob")
+	proto.RegisterMapType((map[string]string)(nil), "io.boss.v1.Blob.AnnotationsEntry")
+	proto.RegisterType((*TransferResponse)(nil), "io.boss.v1.TransferResponse")
+	proto.RegisterType((*EstimateCheckpointRequest)(nil), "io.boss.v1.EstimateCheckpointRequest")
+	proto.RegisterType((*EstimateCheckpointResponse)(nil), "io.boss.v1.EstimateCheckpointResponse")
+	proto.RegisterType((*VolumeEstimate)(nil), "io.boss.v1.VolumeEstimate")
+	proto.RegisterType((*CheckpointsRequest)(nil), "io.boss.v1.CheckpointsRequest")
+	proto.RegisterType((*CheckpointsResponse)(nil), "io.boss.v1.CheckpointsResponse")
+	proto.RegisterType((*CheckpointInfo)(nil), "io.boss.v1.CheckpointInfo")
+	proto.RegisterType((*InspectCheckpointRequest)(nil), "io.boss.v1.InspectCheckpointRequest")
+	proto.RegisterType((*InspectCheckpointResponse)(nil), "io.boss.v1.InspectCheckpointResponse")
+	proto.RegisterType((*DeleteCheckpointRequest)(nil), "io.boss.v1.DeleteCheckpointRequest")
+	proto.RegisterType((*ExportCheckpointRequest)(nil), "io.boss.v1.ExportCheckpointRequest")
+	proto.RegisterType((*ExportCheckpointResponse)(nil), "io.boss.v1.ExportCheckpointResponse")
+	proto.RegisterType((*ImportCheckpointRequest)(nil), "io.boss.v1.ImportCheckpointRequest")
+	proto.RegisterType((*ImportCheckpointResponse)(nil), "io.boss.v1.ImportCheckpointResponse")
+	proto.RegisterType((*JobsRequest)(nil), "io.boss.v1.JobsRequest")
+	proto.RegisterType((*JobsResponse)(nil), "io.boss.v1.JobsResponse")
+	proto.RegisterType((*Job)(nil), "io.boss.v1.Job")
+	proto.RegisterType((*JobRun)(nil), "io.boss.v1.JobRun")
+	proto.RegisterType((*JobHistory)(nil), "io.boss.v1.JobHistory")
+	proto.RegisterType((*RunJobRequest)(nil), "io.boss.v1.RunJobRequest")
 	proto.RegisterType((*Container)(nil), "io.boss.v1.Container")
 	proto.RegisterMapType((map[string]*Config)(nil), "io.boss.v1.Container.ConfigsEntry")
 	proto.RegisterMapType((map[string]*Service)(nil), "io.boss.v1.Container.ServicesEntry")
+	proto.RegisterType((*Policy)(nil), "io.boss.v1.Policy")
+	proto.RegisterType((*PolicyRule)(nil), "io.boss.v1.PolicyRule")
+	proto.RegisterType((*PortMapping)(nil), "io.boss.v1.PortMapping")
+	proto.RegisterType((*Sidecar)(nil), "io.boss.v1.Sidecar")
+	proto.RegisterType((*Hook)(nil), "io.boss.v1.Hook")
+	proto.RegisterType((*InitContainer)(nil), "io.boss.v1.InitContainer")
+	proto.RegisterType((*RestartPolicy)(nil), "io.boss.v1.RestartPolicy")
+	proto.RegisterType((*Security)(nil), "io.boss.v1.Security")
+	proto.RegisterType((*UserNamespace)(nil), "io.boss.v1.UserNamespace")
 	proto.RegisterType((*Volume)(nil), "io.boss.v1.Volume")
 	proto.RegisterType((*Config)(nil), "io.boss.v1.Config")
 	proto.RegisterType((*Service)(nil), "io.boss.v1.Service")
+	proto.RegisterType((*Ingress)(nil), "io.boss.v1.Ingress")
 	proto.RegisterType((*HealthCheck)(nil), "io.boss.v1.HealthCheck")
 	proto.RegisterType((*GPUs)(nil), "io.boss.v1.GPUs")
 	proto.RegisterType((*Resources)(nil), "io.boss.v1.Resources")
+	proto.RegisterType((*WeightDevice)(nil), "io.boss.v1.WeightDevice")
+	proto.RegisterType((*ThrottleDevice)(nil), "io.boss.v1.ThrottleDevice")
+	proto.RegisterType((*Rlimit)(nil), "io.boss.v1.Rlimit")
 	proto.RegisterType((*Mount)(nil), "io.boss.v1.Mount")
 	proto.RegisterType((*Process)(nil), "io.boss.v1.Process")
 	proto.RegisterType((*User)(nil), "io.boss.v1.User")
@@ -1905,7 +4343,17 @@ type AgentClient interface {
 	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
 	Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*MigrateResponse, error)
+	PreCopy(ctx context.Context, opts ...grpc.CallOption) (Agent_PreCopyClient, error)
+	Transfer(ctx context.Context, opts ...grpc.CallOption) (Agent_TransferClient, error)
+	EstimateCheckpoint(ctx context.Context, in *EstimateCheckpointRequest, opts ...grpc.CallOption) (*EstimateCheckpointResponse, error)
+	Checkpoints(ctx context.Context, in *CheckpointsRequest, opts ...grpc.CallOption) (*CheckpointsResponse, error)
+	InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error)
+	DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ExportCheckpoint(ctx context.Context, in *ExportCheckpointRequest, opts ...grpc.CallOption) (Agent_ExportCheckpointClient, error)
+	ImportCheckpoint(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportCheckpointClient, error)
 	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
+	Jobs(ctx context.Context, in *JobsRequest, opts ...grpc.CallOption) (*JobsResponse, error)
+	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*types.Empty, error)
 }
 
 type agentClient struct {
@@ -2042,6 +4490,176 @@ func (c *agentClient) Migrate(ctx context.Context, in *MigrateRequest, opts ...g
 	return out, nil
 }
 
+func (c *agentClient) PreCopy(ctx context.Context, opts ...grpc.CallOption) (Agent_PreCopyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/io.boss.v1.Agent/PreCopy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentPreCopyClient{stream}
+	return x, nil
+}
+
+type Agent_PreCopyClient interface {
+	Send(*PreCopyRequest) error
+	CloseAndRecv() (*PreCopyResponse, error)
+	grpc.ClientStream
+}
+
+type agentPreCopyClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentPreCopyClient) Send(m *PreCopyRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentPreCopyClient) CloseAndRecv() (*PreCopyResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PreCopyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) Transfer(ctx context.Context, opts ...grpc.CallOption) (Agent_TransferClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[1], "/io.boss.v1.Agent/Transfer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentTransferClient{stream}
+	return x, nil
+}
+
+type Agent_TransferClient interface {
+	Send(*TransferRequest) error
+	CloseAndRecv() (*TransferResponse, error)
+	grpc.ClientStream
+}
+
+type agentTransferClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentTransferClient) Send(m *TransferRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentTransferClient) CloseAndRecv() (*TransferResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TransferResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) EstimateCheckpoint(ctx context.Context, in *EstimateCheckpointRequest, opts ...grpc.CallOption) (*EstimateCheckpointResponse, error) {
+	out := new(EstimateCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/EstimateCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Checkpoints(ctx context.Context, in *CheckpointsRequest, opts ...grpc.CallOption) (*CheckpointsResponse, error) {
+	out := new(CheckpointsResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Checkpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error) {
+	out := new(InspectCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/InspectCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/DeleteCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExportCheckpoint(ctx context.Context, in *ExportCheckpointRequest, opts ...grpc.CallOption) (Agent_ExportCheckpointClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[2], "/io.boss.v1.Agent/ExportCheckpoint", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentExportCheckpointClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_ExportCheckpointClient interface {
+	Recv() (*ExportCheckpointResponse, error)
+	grpc.ClientStream
+}
+
+type agentExportCheckpointClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentExportCheckpointClient) Recv() (*ExportCheckpointResponse, error) {
+	m := new(ExportCheckpointResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ImportCheckpoint(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportCheckpointClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[3], "/io.boss.v1.Agent/ImportCheckpoint", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentImportCheckpointClient{stream}
+	return x, nil
+}
+
+type Agent_ImportCheckpointClient interface {
+	Send(*ImportCheckpointRequest) error
+	CloseAndRecv() (*ImportCheckpointResponse, error)
+	grpc.ClientStream
+}
+
+type agentImportCheckpointClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentImportCheckpointClient) Send(m *ImportCheckpointRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentImportCheckpointClient) CloseAndRecv() (*ImportCheckpointResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportCheckpointResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *agentClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
 	out := new(NodesResponse)
 	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Nodes", in, out, opts...)
@@ -2051,6 +4669,24 @@ func (c *agentClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *agentClient) Jobs(ctx context.Context, in *JobsRequest, opts ...grpc.CallOption) (*JobsResponse, error) {
+	out := new(JobsResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Jobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/RunJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	Create(context.Context, *CreateRequest) (*types.Empty, error)
@@ -2067,7 +4703,17 @@ type AgentServer interface {
 	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
 	Migrate(context.Context, *MigrateRequest) (*MigrateResponse, error)
+	PreCopy(Agent_PreCopyServer) error
+	Transfer(Agent_TransferServer) error
+	EstimateCheckpoint(context.Context, *EstimateCheckpointRequest) (*EstimateCheckpointResponse, error)
+	Checkpoints(context.Context, *CheckpointsRequest) (*CheckpointsResponse, error)
+	InspectCheckpoint(context.Context, *InspectCheckpointRequest) (*InspectCheckpointResponse, error)
+	DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*types.Empty, error)
+	ExportCheckpoint(*ExportCheckpointRequest, Agent_ExportCheckpointServer) error
+	ImportCheckpoint(Agent_ImportCheckpointServer) error
 	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	Jobs(context.Context, *JobsRequest) (*JobsResponse, error)
+	RunJob(context.Context, *RunJobRequest) (*types.Empty, error)
 }
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
@@ -2326,6 +4972,177 @@ func _Agent_Migrate_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_PreCopy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).PreCopy(&agentPreCopyServer{stream})
+}
+
+type Agent_PreCopyServer interface {
+	SendAndClose(*PreCopyResponse) error
+	Recv() (*PreCopyRequest, error)
+	grpc.ServerStream
+}
+
+type agentPreCopyServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentPreCopyServer) SendAndClose(m *PreCopyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentPreCopyServer) Recv() (*PreCopyRequest, error) {
+	m := new(PreCopyRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_Transfer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).Transfer(&agentTransferServer{stream})
+}
+
+type Agent_TransferServer interface {
+	SendAndClose(*TransferResponse) error
+	Recv() (*TransferRequest, error)
+	grpc.ServerStream
+}
+
+type agentTransferServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentTransferServer) SendAndClose(m *TransferResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentTransferServer) Recv() (*TransferRequest, error) {
+	m := new(TransferRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_EstimateCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).EstimateCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/EstimateCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).EstimateCheckpoint(ctx, req.(*EstimateCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_Checkpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Checkpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Checkpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Checkpoints(ctx, req.(*CheckpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_InspectCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).InspectCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/InspectCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).InspectCheckpoint(ctx, req.(*InspectCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/DeleteCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteCheckpoint(ctx, req.(*DeleteCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ExportCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCheckpointRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).ExportCheckpoint(m, &agentExportCheckpointServer{stream})
+}
+
+type Agent_ExportCheckpointServer interface {
+	Send(*ExportCheckpointResponse) error
+	grpc.ServerStream
+}
+
+type agentExportCheckpointServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentExportCheckpointServer) Send(m *ExportCheckpointResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_ImportCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).ImportCheckpoint(&agentImportCheckpointServer{stream})
+}
+
+type Agent_ImportCheckpointServer interface {
+	SendAndClose(*ImportCheckpointResponse) error
+	Recv() (*ImportCheckpointRequest, error)
+	grpc.ServerStream
+}
+
+type agentImportCheckpointServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentImportCheckpointServer) SendAndClose(m *ImportCheckpointResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentImportCheckpointServer) Recv() (*ImportCheckpointRequest, error) {
+	m := new(ImportCheckpointRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Agent_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NodesRequest)
 	if err := dec(in); err != nil {
@@ -2344,6 +5161,42 @@ func _Agent_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_Jobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Jobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Jobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Jobs(ctx, req.(*JobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/RunJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RunJob(ctx, req.(*RunJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "io.boss.v1.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -2404,122 +5257,290 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Migrate",
 			Handler:    _Agent_Migrate_Handler,
 		},
+		{
+			MethodName: "EstimateCheckpoint",
+			Handler:    _Agent_EstimateCheckpoint_Handler,
+		},
+		{
+			MethodName: "Checkpoints",
+			Handler:    _Agent_Checkpoints_Handler,
+		},
+		{
+			MethodName: "InspectCheckpoint",
+			Handler:    _Agent_InspectCheckpoint_Handler,
+		},
+		{
+			MethodName: "DeleteCheckpoint",
+			Handler:    _Agent_DeleteCheckpoint_Handler,
+		},
 		{
 			MethodName: "Nodes",
 			Handler:    _Agent_Nodes_Handler,
 		},
+		{
+			MethodName: "Jobs",
+			Handler:    _Agent_Jobs_Handler,
+		},
+		{
+			MethodName: "RunJob",
+			Handler:    _Agent_RunJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PreCopy",
+			Handler:       _Agent_PreCopy_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Transfer",
+			Handler:       _Agent_Transfer_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCheckpoint",
+			Handler:       _Agent_ExportCheckpoint_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCheckpoint",
+			Handler:       _Agent_ImportCheckpoint_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/crosbymichael/boss/api/v1/boss.proto",
 }
 
 func init() {
-	proto.RegisterFile("github.com/crosbymichael/boss/api/v1/boss.proto", fileDescriptor_boss_0c2fc37a348abd77)
-}
-
-var fileDescriptor_boss_0c2fc37a348abd77 = []byte{
-	// 1647 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x18, 0xed, 0x72, 0xdb, 0x4a,
-	0xf5, 0xfa, 0xdb, 0x3e, 0x8e, 0x73, 0xd3, 0x25, 0xa4, 0xba, 0xca, 0x85, 0x18, 0x51, 0xee, 0x4d,
-	0x18, 0x6a, 0x4f, 0x53, 0x68, 0x29, 0x2d, 0x74, 0x9a, 0x8f, 0x86, 0x4e, 0x3f, 0x26, 0xb3, 0x21,
-	0x0c, 0xc3, 0x30, 0xd3, 0x91, 0xad, 0xb5, 0xbd, 0x13, 0x59, 0x2b, 0xb4, 0x2b, 0x17, 0xf7, 0x11,
-	0xf8, 0xc5, 0x3f, 0x9e, 0x81, 0x37, 0xe0, 0x11, 0x78, 0x8a, 0x32, 0xc3, 0x2b, 0xf0, 0x93, 0x3f,
-	0xcc, 0x59, 0xad, 0x64, 0xc9, 0xb1, 0x9b, 0x42, 0xff, 0x9d, 0xb3, 0xe7, 0x7b, 0x75, 0xf6, 0x7c,
-	0x08, 0xfa, 0x63, 0xae, 0x26, 0xf1, 0xa0, 0x37, 0x14, 0xd3, 0xfe, 0x30, 0x12, 0x72, 0x30, 0x9f,
-	0xf2, 0xe1, 0xc4, 0x65, 0x7e, 0x7f, 0x20, 0xa4, 0xec, 0xbb, 0x21, 0xef, 0xcf, 0xee, 0x69, 0xb8,
-	0x17, 0x46, 0x42, 0x09, 0x02, 0x5c, 0xf4, 0x34, 0x3a, 0xbb, 0x67, 0x6f, 0x8f, 0xc5, 0x58, 0xe8,
-	0xe3, 0x3e, 0x42, 0x09, 0x87, 0xbd, 0x3b, 0x16, 0x62, 0xec, 0xb3, 0xbe, 0xc6, 0x06, 0xf1, 0xa8,
-	0xcf, 0xa6, 0xa1, 0x9a, 0x1b, 0xe2, 0xde, 0x32, 0x51, 0xf1, 0x29, 0x93, 0xca, 0x9d, 0x86, 0x09,
-	0x83, 0xf3, 0x07, 0xe8, 0x1c, 0x47, 0xcc, 0x55, 0x8c, 0xb2, 0x3f, 0xc6, 0x4c, 0x2a, 0x72, 0x1f,
-	0x5a, 0x43, 0x11, 0x28, 0x97, 0x07, 0x2c, 0xb2, 0x4a, 0xdd, 0xd2, 0x7e, 0xfb, 0xf0, 0xbb, 0xbd,
-	0x85, 0x13, 0xbd, 0xe3, 0x94, 0x48, 0x17, 0x7c, 0x64, 0x07, 0xea, 0x71, 0xe8, 0xb9, 0x8a, 0x59,
-	0xe5, 0x6e, 0x69, 0xbf, 0x49, 0x0d, 0xe6, 0x7c, 0x0b, 0x9d, 0x13, 0xe6, 0xb3, 0x85, 0xf6, 0x1d,
-	0x28, 0x73, 0x4f, 0xab, 0x6d, 0x1d, 0xd5, 0xff, 0xf5, 0x61, 0xaf, 0xfc, 0xe2, 0x84, 0x96, 0xb9,
-	0xe7, 0xdc, 0x01, 0x38, 0x63, 0xea, 0x26, 0xae, 0xe7, 0xd0, 0xd6, 0x5c, 0x32, 0x14, 0x81, 0x64,
-	0xe4, 0xe1, 0x75, 0x57, 0xbf, 0x5a, 0xe9, 0xea, 0x8b, 0x60, 0x24, 0x72, 0xee, 0x3a, 0xbf, 0x84,
-	0xf6, 0x4b, 0xee, 0xfb, 0x37, 0x98, 0xc3, 0xa8, 0x24, 0x1f, 0x07, 0xae, 0xaf, 0xa3, 0xea, 0x50,
-	0x83, 0x39, 0x1d, 0x68, 0xbf, 0xe2, 0x32, 0xf5, 0xd6, 0x79, 0x01, 0x1b, 0x09, 0x6a, 0xdc, 0x7a,
-	0x04, 0x90, 0x99, 0x92, 0x56, 0xa9, 0x5b, 0xf9, 0xb8, 0x5f, 0x39, 0x66, 0x67, 0x13, 0x36, 0xde,
-	0x08, 0x8f, 0xc9, 0x54, 0xf5, 0x43, 0xe8, 0x18, 0xdc, 0xe8, 0xfe, 0x06, 0x6a, 0x01, 0x1e, 0x18,
-	0xb5, 0x5b, 0x79, 0xb5, 0xc8, 0x49, 0x13, 0xb2, 0xf3, 0xb7, 0x12, 0x54, 0x11, 0x5f, 0x1b, 0x9b,
-	0x05, 0x0d, 0xd7, 0xf3, 0x22, 0x26, 0xa5, 0x0e, 0xae, 0x45, 0x53, 0x94, 0xfc, 0x14, 0xea, 0xbe,
-	0x3b, 0x60, 0xbe, 0xb4, 0x2a, 0xda, 0xc6, 0xd7, 0xcb, 0x36, 0x7a, 0xaf, 0x34, 0xf9, 0x34, 0x50,
-	0xd1, 0x9c, 0x1a, 0x5e, 0xfb, 0x11, 0xb4, 0x73, 0xc7, 0x64, 0x0b, 0x2a, 0x57, 0x6c, 0x9e, 0xd8,
-	0xa5, 0x08, 0x92, 0x6d, 0xa8, 0xcd, 0x5c, 0x3f, 0x66, 0xc6, 0x5c, 0x82, 0xfc, 0xa2, 0xfc, 0xf3,
-	0x92, 0xf3, 0x9f, 0x32, 0x74, 0x0a, 0x57, 0xb2, 0xd6, 0xe9, 0x6d, 0xa8, 0xf1, 0xa9, 0x3b, 0xce,
-	0x74, 0x68, 0x44, 0x7f, 0x26, 0xe5, 0xaa, 0x18, 0x1d, 0xc6, 0x63, 0x83, 0x69, 0x2d, 0xa1, 0x55,
-	0xcd, 0x69, 0x39, 0xa7, 0x65, 0x1e, 0xa2, 0x6f, 0xc3, 0x30, 0xb6, 0x6a, 0xdd, 0xd2, 0x7e, 0x95,
-	0x22, 0x48, 0x7e, 0x00, 0x1b, 0x53, 0x36, 0x15, 0xd1, 0xfc, 0x6d, 0x2c, 0x51, 0x7d, 0xbd, 0x5b,
-	0xda, 0x2f, 0xd1, 0x76, 0x72, 0x76, 0x89, 0x47, 0x39, 0x16, 0x9f, 0x4f, 0xb9, 0xb2, 0x1a, 0x79,
-	0x96, 0x57, 0x78, 0x44, 0x76, 0xa1, 0x15, 0x72, 0xcf, 0xa8, 0x68, 0x6a, 0xed, 0xcd, 0x90, 0x7b,
-	0x89, 0xbc, 0x21, 0x26, 0xc2, 0xad, 0x8c, 0x98, 0x48, 0xde, 0x86, 0xc6, 0x48, 0xbe, 0x95, 0xfc,
-	0x3d, 0xb3, 0xa0, 0x5b, 0xda, 0xaf, 0xd0, 0xfa, 0x48, 0x5e, 0xf0, 0xf7, 0x8c, 0xdc, 0x85, 0xfa,
-	0x50, 0x04, 0x23, 0x3e, 0xb6, 0xda, 0x1f, 0x7b, 0x89, 0x86, 0x89, 0x1c, 0x42, 0x4b, 0x06, 0x6e,
-	0x28, 0x27, 0x42, 0x49, 0x6b, 0x43, 0x7f, 0xbd, 0xed, 0xbc, 0xc4, 0x85, 0x21, 0xd2, 0x05, 0x9b,
-	0xf3, 0xd7, 0x12, 0x34, 0xd3, 0xf3, 0xb5, 0x17, 0xff, 0x2b, 0x68, 0x0c, 0x75, 0x95, 0xf0, 0xf4,
-	0xd5, 0xb7, 0x0f, 0xed, 0x5e, 0x52, 0x58, 0x7a, 0x69, 0x61, 0xe9, 0xfd, 0x26, 0x2d, 0x2c, 0x47,
-	0xcd, 0x7f, 0x7c, 0xd8, 0xfb, 0xe2, 0x2f, 0xff, 0xdc, 0x2b, 0xd1, 0x54, 0x88, 0xd8, 0xd0, 0x0c,
-	0x23, 0x36, 0xe3, 0x22, 0xfb, 0x48, 0x19, 0x9e, 0x0f, 0xbe, 0x9a, 0x0f, 0xde, 0x39, 0x80, 0x2f,
-	0xa9, 0xf0, 0xfd, 0x81, 0x3b, 0xbc, 0xba, 0xa9, 0x30, 0x9c, 0xc1, 0xd6, 0x82, 0xd5, 0x3c, 0x95,
-	0xff, 0xa7, 0x90, 0x39, 0xdf, 0xc0, 0xc6, 0x85, 0x72, 0xa3, 0x1b, 0x2b, 0xd1, 0x8f, 0xa0, 0x7d,
-	0xa1, 0x44, 0x78, 0x13, 0xdb, 0x09, 0x74, 0x2e, 0x75, 0x25, 0xfc, 0x9c, 0xea, 0xea, 0x9c, 0xc2,
-	0x66, 0xaa, 0xe5, 0x73, 0x62, 0xbb, 0x03, 0x5b, 0xe7, 0xb1, 0x9c, 0x1c, 0xc5, 0xdc, 0xf7, 0x52,
-	0x7f, 0xb6, 0xa0, 0x12, 0xb1, 0x51, 0xfa, 0x4e, 0x23, 0x36, 0x72, 0x7e, 0x06, 0x6d, 0xe4, 0x5a,
-	0xcb, 0x80, 0x8f, 0x70, 0x80, 0x2a, 0x4c, 0xa9, 0x4f, 0x10, 0x87, 0xc1, 0xad, 0xe3, 0x09, 0x1b,
-	0x5e, 0x85, 0x82, 0x07, 0x37, 0xdd, 0x5e, 0xaa, 0xb4, 0xbc, 0x50, 0x4a, 0xa0, 0xea, 0xf3, 0x19,
-	0xd3, 0xc9, 0xd1, 0xa4, 0x1a, 0xc6, 0x33, 0xf6, 0x27, 0xae, 0x74, 0x56, 0x34, 0xa9, 0x86, 0x9d,
-	0x6d, 0x20, 0x79, 0x33, 0xc9, 0x75, 0x38, 0x0f, 0x60, 0x93, 0x32, 0xa9, 0x44, 0xc4, 0xd6, 0xbb,
-	0x9d, 0x5a, 0x28, 0x2f, 0x2c, 0x38, 0xb7, 0xe0, 0xcb, 0x4c, 0xce, 0xa8, 0xfa, 0x73, 0x09, 0x36,
-	0x5f, 0xf3, 0x71, 0xe4, 0xde, 0xd8, 0xb3, 0x3e, 0x3d, 0x0a, 0xa9, 0x44, 0x98, 0x46, 0x81, 0x30,
-	0xd9, 0x84, 0xb2, 0x12, 0xba, 0x00, 0xb5, 0x68, 0x59, 0x61, 0xbd, 0xab, 0x7b, 0xba, 0x4d, 0xea,
-	0xca, 0xd3, 0xa4, 0x06, 0x43, 0xff, 0x32, 0x5f, 0x8c, 0x7f, 0xff, 0xae, 0x42, 0xeb, 0x38, 0xd7,
-	0x77, 0xff, 0x97, 0x42, 0x69, 0x41, 0x23, 0x60, 0xea, 0x9d, 0x88, 0xae, 0xcc, 0x23, 0x4c, 0x51,
-	0x72, 0x17, 0x1a, 0x61, 0x24, 0x86, 0xd8, 0x0d, 0xaa, 0x3a, 0x9b, 0xbe, 0x93, 0xcf, 0xa6, 0xf3,
-	0x84, 0x44, 0x53, 0x1e, 0x72, 0x00, 0xf5, 0xa9, 0x88, 0x03, 0x25, 0xad, 0x9a, 0x2e, 0x32, 0xb7,
-	0xf2, 0xdc, 0xaf, 0x91, 0x42, 0x0d, 0x03, 0x66, 0x6a, 0xc4, 0xa4, 0x88, 0xa3, 0x21, 0x93, 0x3a,
-	0xba, 0xa5, 0x4c, 0xa5, 0x29, 0x91, 0x2e, 0xf8, 0xc8, 0x1d, 0xa8, 0x8e, 0xc3, 0x58, 0xea, 0x22,
-	0xbb, 0xd4, 0xe4, 0xce, 0xce, 0x2f, 0x25, 0xd5, 0x54, 0xf2, 0x14, 0x9a, 0x92, 0x45, 0x33, 0x8e,
-	0x9a, 0x9b, 0xda, 0x8f, 0x1f, 0xae, 0x7c, 0x03, 0xbd, 0x0b, 0xc3, 0x95, 0x74, 0xac, 0x4c, 0x88,
-	0x3c, 0x81, 0x46, 0x52, 0x38, 0xa5, 0xd5, 0xd2, 0xf2, 0xce, 0x6a, 0xf9, 0xe3, 0x84, 0x29, 0x11,
-	0x4f, 0x45, 0xb0, 0xa6, 0x45, 0xcc, 0xf5, 0x44, 0xe0, 0xcf, 0x75, 0xd5, 0x6e, 0xd2, 0x0c, 0x27,
-	0x3f, 0x81, 0xc6, 0x4c, 0xf8, 0xf1, 0x94, 0x49, 0xab, 0xad, 0x35, 0x93, 0xbc, 0xe6, 0xdf, 0x6a,
-	0x12, 0x4d, 0x59, 0xec, 0x73, 0xe8, 0x14, 0x5c, 0x5c, 0xd1, 0x3d, 0x0f, 0xf2, 0xdd, 0x73, 0xe9,
-	0xf3, 0x18, 0xd9, 0x5c, 0x4b, 0xb5, 0xdf, 0xc0, 0x46, 0xde, 0xe9, 0x15, 0x0a, 0xf7, 0x8b, 0x0a,
-	0xc9, 0x52, 0xe4, 0x23, 0x3e, 0xce, 0xb7, 0x68, 0x0a, 0xf5, 0xc4, 0xe9, 0xb5, 0x19, 0xd7, 0x85,
-	0xb6, 0xc7, 0xa4, 0xe2, 0x81, 0xab, 0xb8, 0x08, 0x4c, 0xde, 0xe5, 0x8f, 0x30, 0xe9, 0xa3, 0x77,
-	0xe6, 0x69, 0x94, 0xa3, 0x77, 0xce, 0x08, 0xea, 0x89, 0x21, 0x7c, 0x22, 0xa1, 0xab, 0x26, 0xc6,
-	0x3d, 0x0d, 0xeb, 0xa6, 0xae, 0xb3, 0xc1, 0xa8, 0x32, 0x58, 0x6e, 0x26, 0x4b, 0x9b, 0xbd, 0xc6,
-	0x30, 0xb7, 0xb1, 0xd2, 0xb1, 0x20, 0xa9, 0x17, 0x2d, 0x9a, 0xa2, 0xce, 0x0c, 0x1a, 0xe6, 0x86,
-	0xb4, 0x21, 0x11, 0x29, 0x6d, 0xa8, 0x42, 0x35, 0x8c, 0x0a, 0xcd, 0xb8, 0x53, 0xee, 0x56, 0x50,
-	0x61, 0x82, 0xe1, 0x95, 0xc5, 0x51, 0x6a, 0x05, 0x41, 0x72, 0x17, 0x6a, 0x43, 0xac, 0x3d, 0xe6,
-	0x89, 0xdc, 0xce, 0x5f, 0xd9, 0xaf, 0x99, 0xeb, 0xab, 0x89, 0x2e, 0x4d, 0x34, 0xe1, 0x72, 0x04,
-	0xb4, 0x73, 0xa7, 0x68, 0x5b, 0xcd, 0x43, 0x96, 0x06, 0x89, 0x30, 0xa6, 0x10, 0x0f, 0x14, 0x8b,
-	0x66, 0x66, 0xc4, 0xac, 0xd0, 0x0c, 0xc7, 0x80, 0x70, 0x56, 0x17, 0xb1, 0xd2, 0x3e, 0x54, 0x68,
-	0x8a, 0xa2, 0xc7, 0x53, 0xa6, 0x26, 0xc2, 0x33, 0x91, 0x1a, 0xcc, 0x39, 0x81, 0x2a, 0xbe, 0x0e,
-	0x94, 0xf4, 0x58, 0xf2, 0x2c, 0x70, 0x4a, 0xac, 0xd0, 0x14, 0x25, 0x0e, 0x6c, 0x0c, 0xdd, 0xd0,
-	0x1d, 0x70, 0x9f, 0x2b, 0xce, 0xd2, 0x88, 0x0b, 0x67, 0xce, 0x08, 0x5a, 0xd9, 0x9b, 0x44, 0xa7,
-	0x87, 0xf8, 0x10, 0x4b, 0x7a, 0xda, 0xd1, 0x70, 0x62, 0x1e, 0xa7, 0x1e, 0xe3, 0xb2, 0xc1, 0xb0,
-	0xe6, 0xc8, 0xa1, 0x88, 0x98, 0x71, 0x37, 0x41, 0xb0, 0xbb, 0x07, 0xe2, 0xed, 0x88, 0xfb, 0x49,
-	0x77, 0xaf, 0xd2, 0x7a, 0x20, 0x9e, 0x73, 0x9f, 0x39, 0x02, 0x6a, 0xba, 0x52, 0xac, 0xbc, 0x98,
-	0x75, 0x5f, 0x7f, 0x29, 0xcb, 0x2a, 0xd7, 0xb3, 0xcc, 0x82, 0x86, 0x08, 0x11, 0xc2, 0x4a, 0x86,
-	0xd1, 0xa5, 0xa8, 0x33, 0x87, 0x86, 0x29, 0x64, 0x58, 0x5f, 0x62, 0x99, 0x75, 0xce, 0x42, 0x7d,
-	0xb9, 0x94, 0x2c, 0xa2, 0x9a, 0x8a, 0x8e, 0xb9, 0xd1, 0x38, 0xbd, 0x25, 0x0d, 0x63, 0x56, 0xb0,
-	0x60, 0xa6, 0x27, 0xe3, 0x16, 0x45, 0xf0, 0xda, 0x9d, 0x56, 0x57, 0xdc, 0xe9, 0x8f, 0xa1, 0x8a,
-	0x7a, 0x75, 0x4e, 0x99, 0xd7, 0xd3, 0xa1, 0x08, 0xe2, 0xc9, 0x98, 0x7b, 0x66, 0xbf, 0x40, 0xf0,
-	0xf0, 0xef, 0x0d, 0xa8, 0x3d, 0x1b, 0xb3, 0x40, 0x91, 0xc7, 0x50, 0x4f, 0x56, 0x33, 0x52, 0xdc,
-	0x1e, 0xf2, 0xeb, 0x9a, 0xbd, 0x73, 0x6d, 0x10, 0x3b, 0xc5, 0xf5, 0x0f, 0x85, 0x93, 0xcd, 0xab,
-	0x28, 0x5c, 0xd8, 0xc6, 0xd6, 0x0a, 0x3f, 0x80, 0xca, 0x19, 0x53, 0x64, 0xa7, 0x50, 0x78, 0xb3,
-	0xf5, 0xcc, 0xbe, 0x7d, 0xed, 0x3c, 0x5b, 0xc8, 0xaa, 0xb8, 0x57, 0x91, 0x02, 0x43, 0x6e, 0xd3,
-	0x5a, 0x6b, 0xf0, 0x11, 0x54, 0x71, 0x85, 0x2a, 0x0a, 0xe6, 0x76, 0x2c, 0xdb, 0xba, 0x4e, 0x30,
-	0x36, 0x4f, 0xa1, 0x99, 0x8e, 0x7e, 0x64, 0xb7, 0xd0, 0x59, 0x8a, 0xb3, 0xa3, 0xfd, 0xf5, 0x6a,
-	0x62, 0xb6, 0xb4, 0xd5, 0xf4, 0xe0, 0x47, 0x0a, 0x96, 0xf2, 0xb3, 0xe0, 0x5a, 0xe7, 0x1f, 0x42,
-	0x15, 0x67, 0xc1, 0xa2, 0xf3, 0xb9, 0xe9, 0x70, 0xad, 0xe0, 0x53, 0xa8, 0x27, 0x73, 0x5d, 0xf1,
-	0x1b, 0x15, 0x26, 0x46, 0xdb, 0x5e, 0x45, 0x32, 0x4e, 0x3f, 0x83, 0x56, 0x36, 0xd1, 0x91, 0x42,
-	0x7c, 0xcb, 0x83, 0xde, 0xc7, 0x9c, 0x47, 0xde, 0xa2, 0xf3, 0xb9, 0x01, 0x70, 0xad, 0xe0, 0x4b,
-	0x80, 0xc5, 0x24, 0x46, 0xbe, 0x57, 0xc8, 0xd0, 0xe5, 0x41, 0xd0, 0xfe, 0xfe, 0x3a, 0xb2, 0x09,
-	0xe4, 0x08, 0x1a, 0x66, 0x10, 0x23, 0xf6, 0xd2, 0x74, 0x90, 0x9b, 0xea, 0xec, 0xdd, 0x95, 0xb4,
-	0x85, 0x0e, 0x33, 0x2c, 0x15, 0x75, 0x14, 0xa7, 0xb9, 0xa2, 0x8e, 0xa5, 0xe9, 0x8a, 0x3c, 0x81,
-	0x9a, 0xde, 0xb7, 0x8b, 0x59, 0x90, 0x5f, 0xc9, 0xed, 0xaf, 0x56, 0x50, 0x12, 0xe9, 0xa3, 0x83,
-	0xdf, 0x7f, 0xfb, 0x29, 0xbf, 0x77, 0x1e, 0xcf, 0xee, 0xfd, 0xee, 0x8b, 0x41, 0x5d, 0xdf, 0xe7,
-	0xfd, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x64, 0xeb, 0xfc, 0x4d, 0x12, 0x12, 0x00, 0x00,
+	proto.RegisterFile("github.com/crosbymichael/boss/api/v1/boss.proto", fileDescriptor_boss_86c583f9ab470e68)
+}
+
+var fileDescriptor_boss_86c583f9ab470e68 = []byte{
+	// 3605 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3a, 0xcb, 0x72, 0x1c, 0x47,
+	0x72, 0xdb, 0x33, 0x3d, 0xaf, 0x1c, 0x0c, 0x1e, 0x25, 0x2c, 0xd9, 0x1c, 0x6a, 0x0d, 0x6c, 0x8b,
+	0x0f, 0xd0, 0x12, 0x01, 0x89, 0x94, 0xa5, 0x95, 0x28, 0xaf, 0x42, 0x00, 0x28, 0x12, 0x5c, 0x91,
+	0x8b, 0x28, 0x90, 0x96, 0xc3, 0x61, 0xc7, 0xb8, 0x67, 0xba, 0x30, 0xe8, 0x45, 0x4f, 0x57, 0xbb,
+	0xab, 0x07, 0xe0, 0xec, 0x1e, 0xfc, 0x0b, 0x0e, 0xdb, 0x11, 0xf6, 0xd5, 0x07, 0x1f, 0x7c, 0xd9,
+	0x7f, 0xf0, 0xcd, 0x67, 0x1f, 0x7c, 0xe4, 0x46, 0xd0, 0x17, 0xfb, 0x17, 0x7c, 0x70, 0x6c, 0x64,
+	0x3d, 0xfa, 0x31, 0x0f, 0x00, 0x5c, 0xdd, 0x2a, 0x9f, 0x55, 0x95, 0x95, 0x95, 0x95, 0x95, 0x55,
+	0xb0, 0x33, 0x0c, 0xd2, 0x93, 0x71, 0x7f, 0x7b, 0xc0, 0x47, 0x3b, 0x83, 0x84, 0x8b, 0xfe, 0x64,
+	0x14, 0x0c, 0x4e, 0x3c, 0x16, 0xee, 0xf4, 0xb9, 0x10, 0x3b, 0x5e, 0x1c, 0xec, 0x9c, 0x7d, 0x22,
+	0xdb, 0xdb, 0x71, 0xc2, 0x53, 0x4e, 0x20, 0xe0, 0xdb, 0x12, 0x3c, 0xfb, 0xa4, 0xbb, 0x3e, 0xe4,
+	0x43, 0x2e, 0xd1, 0x3b, 0xd8, 0x52, 0x1c, 0xdd, 0x9b, 0x43, 0xce, 0x87, 0x21, 0xdb, 0x91, 0x50,
+	0x7f, 0x7c, 0xbc, 0xc3, 0x46, 0x71, 0x3a, 0xd1, 0xc4, 0x8d, 0x69, 0x62, 0x1a, 0x8c, 0x98, 0x48,
+	0xbd, 0x51, 0xac, 0x18, 0xdc, 0xbf, 0x84, 0xce, 0x5e, 0xc2, 0xbc, 0x94, 0x51, 0xf6, 0x37, 0x63,
+	0x26, 0x52, 0xf2, 0x10, 0x5a, 0x03, 0x1e, 0xa5, 0x5e, 0x10, 0xb1, 0xc4, 0xb1, 0x36, 0xad, 0xad,
+	0xf6, 0x83, 0x1f, 0x6f, 0xe7, 0x83, 0xd8, 0xde, 0x33, 0x44, 0x9a, 0xf3, 0x91, 0x6b, 0x50, 0x1f,
+	0xc7, 0xbe, 0x97, 0x32, 0xa7, 0xb2, 0x69, 0x6d, 0x35, 0xa9, 0x86, 0xdc, 0xbb, 0xd0, 0xd9, 0x67,
+	0x21, 0xcb, 0xb5, 0x5f, 0x83, 0x4a, 0xe0, 0x4b, 0xb5, 0xad, 0xdd, 0xfa, 0xdb, 0x37, 0x1b, 0x95,
+	0x83, 0x7d, 0x5a, 0x09, 0x7c, 0xf7, 0x16, 0xc0, 0x13, 0x96, 0x5e, 0xc6, 0xf5, 0x2d, 0xb4, 0x25,
+	0x97, 0x88, 0x79, 0x24, 0x18, 0xf9, 0x7c, 0x76, 0xa8, 0x37, 0xe6, 0x0e, 0xf5, 0x20, 0x3a, 0xe6,
+	0x85, 0xe1, 0xba, 0x7f, 0x0a, 0xed, 0x5f, 0x04, 0x61, 0x78, 0x49, 0x77, 0x38, 0x2b, 0x11, 0x0c,
+	0x23, 0x2f, 0x94, 0xb3, 0xea, 0x50, 0x0d, 0xb9, 0x1d, 0x68, 0x7f, 0x17, 0x08, 0x33, 0x5a, 0xf7,
+	0x00, 0x96, 0x14, 0xa8, 0x87, 0xf5, 0x05, 0x40, 0xd6, 0x95, 0x70, 0xac, 0xcd, 0xea, 0xc5, 0xe3,
+	0x2a, 0x30, 0xbb, 0xcb, 0xb0, 0xf4, 0x82, 0xfb, 0x4c, 0x18, 0xd5, 0x9f, 0x43, 0x47, 0xc3, 0x5a,
+	0xf7, 0x1d, 0xa8, 0x45, 0x88, 0xd0, 0x6a, 0x57, 0x8b, 0x6a, 0x91, 0x93, 0x2a, 0xb2, 0xfb, 0x6f,
+	0x16, 0xd8, 0x08, 0x2f, 0x9c, 0x9b, 0x03, 0x0d, 0xcf, 0xf7, 0x13, 0x26, 0x84, 0x9c, 0x5c, 0x8b,
+	0x1a, 0x90, 0x7c, 0x0a, 0xf5, 0xd0, 0xeb, 0xb3, 0x50, 0x38, 0x55, 0xd9, 0xc7, 0xfb, 0xd3, 0x7d,
+	0x6c, 0x7f, 0x27, 0xc9, 0x8f, 0xa3, 0x34, 0x99, 0x50, 0xcd, 0xdb, 0xfd, 0x02, 0xda, 0x05, 0x34,
+	0x59, 0x85, 0xea, 0x29, 0x9b, 0xa8, 0x7e, 0x29, 0x36, 0xc9, 0x3a, 0xd4, 0xce, 0xbc, 0x70, 0xcc,
+	0x74, 0x77, 0x0a, 0xf8, 0xb2, 0xf2, 0x33, 0xcb, 0xfd, 0x6d, 0x0d, 0x3a, 0x25, 0x93, 0x2c, 0x1c,
+	0xf4, 0x3a, 0xd4, 0x82, 0x91, 0x37, 0xcc, 0x74, 0x48, 0x40, 0x2e, 0x53, 0xea, 0xa5, 0x63, 0x1c,
+	0x30, 0xa2, 0x35, 0x24, 0xb5, 0xc4, 0x8e, 0x5d, 0xd0, 0x72, 0x48, 0x2b, 0x41, 0x8c, 0x63, 0x1b,
+	0xc4, 0x63, 0xa7, 0xb6, 0x69, 0x6d, 0xd9, 0x14, 0x9b, 0xe4, 0xa7, 0xb0, 0x34, 0x62, 0x23, 0x9e,
+	0x4c, 0x7a, 0x63, 0x81, 0xea, 0xeb, 0x9b, 0xd6, 0x96, 0x45, 0xdb, 0x0a, 0xf7, 0x0a, 0x51, 0x05,
+	0x96, 0x30, 0x18, 0x05, 0xa9, 0xd3, 0x28, 0xb2, 0x7c, 0x87, 0x28, 0x72, 0x13, 0x5a, 0x71, 0xe0,
+	0x6b, 0x15, 0x4d, 0xa9, 0xbd, 0x19, 0x07, 0xbe, 0x92, 0xd7, 0x44, 0x25, 0xdc, 0xca, 0x88, 0x4a,
+	0xf2, 0x3a, 0x34, 0x8e, 0x45, 0x4f, 0x04, 0xbf, 0x66, 0x0e, 0x6c, 0x5a, 0x5b, 0x55, 0x5a, 0x3f,
+	0x16, 0x47, 0xc1, 0xaf, 0x19, 0xb9, 0x0f, 0xf5, 0x01, 0x8f, 0x8e, 0x83, 0xa1, 0xd3, 0xbe, 0x68,
+	0x27, 0x6a, 0x26, 0xf2, 0x00, 0x5a, 0x22, 0xf2, 0x62, 0x71, 0xc2, 0x53, 0xe1, 0x2c, 0xc9, 0xd5,
+	0x5b, 0x2f, 0x4a, 0x1c, 0x69, 0x22, 0xcd, 0xd9, 0x48, 0x17, 0x9a, 0x09, 0x46, 0x84, 0x24, 0x15,
+	0x4e, 0x47, 0x8d, 0xcb, 0xc0, 0x48, 0x3b, 0xf6, 0x82, 0x70, 0x9c, 0x30, 0xe1, 0x2c, 0x2b, 0x9a,
+	0x81, 0xc9, 0x2d, 0x58, 0x0e, 0x3d, 0x91, 0xf6, 0xd8, 0xeb, 0x20, 0xed, 0x0d, 0xb8, 0xcf, 0x9c,
+	0x95, 0x4d, 0x6b, 0xab, 0x46, 0x97, 0x10, 0xfb, 0xf8, 0x75, 0x90, 0xee, 0xa1, 0xfb, 0x3d, 0x84,
+	0xa6, 0x08, 0x7c, 0x36, 0xf0, 0x12, 0xe1, 0xac, 0xca, 0x01, 0x5d, 0x2f, 0x0d, 0x48, 0xd1, 0xe4,
+	0x3e, 0xc8, 0x18, 0xc9, 0x1e, 0x34, 0x23, 0x96, 0x9e, 0xf3, 0xe4, 0x54, 0x38, 0x6b, 0x52, 0xe8,
+	0xee, 0xc2, 0xed, 0xb3, 0xfd, 0x42, 0x73, 0x2a, 0x77, 0xcc, 0x04, 0xc9, 0xfb, 0x60, 0x07, 0xf1,
+	0xd9, 0x67, 0x0e, 0x91, 0xeb, 0xdf, 0x7c, 0xfb, 0x66, 0xc3, 0x3e, 0x38, 0x3c, 0xfb, 0x8c, 0x4a,
+	0x6c, 0xf7, 0x11, 0x74, 0x4a, 0x82, 0xef, 0xe4, 0xb0, 0xbf, 0x84, 0x76, 0x61, 0xe0, 0x84, 0x80,
+	0x1d, 0x79, 0x23, 0xa6, 0x65, 0x65, 0xfb, 0xdd, 0x3c, 0xd5, 0xfd, 0x27, 0x0b, 0x9a, 0x66, 0x6d,
+	0x16, 0x3a, 0xff, 0xcf, 0xa1, 0x31, 0x90, 0x91, 0xda, 0x97, 0x4a, 0xdb, 0x0f, 0xba, 0xdb, 0x2a,
+	0xb8, 0x6f, 0x9b, 0xe0, 0xbe, 0xfd, 0xd2, 0x04, 0xf7, 0xdd, 0xe6, 0x7f, 0xbc, 0xd9, 0xf8, 0xd1,
+	0xdf, 0xfd, 0x6e, 0xc3, 0xa2, 0x46, 0x08, 0x17, 0x33, 0x4e, 0xd8, 0x59, 0xc0, 0xb3, 0xee, 0x33,
+	0xb8, 0xe8, 0x80, 0x76, 0xd1, 0x01, 0xdd, 0x7b, 0xb0, 0x42, 0x79, 0x18, 0xf6, 0xbd, 0xc1, 0xe9,
+	0x65, 0xc1, 0xf9, 0x09, 0xac, 0xe6, 0xac, 0x3a, 0x5c, 0xfd, 0x21, 0x87, 0x89, 0x7b, 0x07, 0x96,
+	0x8e, 0xd0, 0xff, 0x2e, 0xeb, 0xf0, 0x36, 0xb4, 0x8f, 0x52, 0x1e, 0x5f, 0xc6, 0xb6, 0x0f, 0x9d,
+	0x57, 0xf2, 0x34, 0xfa, 0x21, 0x27, 0x9c, 0xfb, 0x57, 0xb0, 0x6c, 0xb4, 0xfc, 0x80, 0xb9, 0xa1,
+	0x07, 0x78, 0x83, 0x34, 0xe0, 0x91, 0x76, 0x0c, 0x0d, 0xb9, 0xb7, 0x60, 0xf5, 0x70, 0x2c, 0x4e,
+	0x76, 0xc7, 0x41, 0xe8, 0x9b, 0x71, 0xae, 0x42, 0x35, 0x61, 0xc7, 0xc6, 0x25, 0x13, 0x76, 0xec,
+	0xfe, 0x09, 0xb4, 0x91, 0x6b, 0x21, 0x03, 0xba, 0x5d, 0x1f, 0x55, 0xe8, 0x63, 0x58, 0x01, 0xee,
+	0xdf, 0xc2, 0xda, 0xde, 0x09, 0x1b, 0x9c, 0xc6, 0x3c, 0x88, 0x2e, 0xb3, 0xaa, 0x51, 0x5a, 0xc9,
+	0x95, 0x12, 0xb0, 0xc3, 0xe0, 0x8c, 0x49, 0xa7, 0x69, 0x52, 0xd9, 0x46, 0x1c, 0x6e, 0x7c, 0xe9,
+	0x2d, 0x4d, 0x2a, 0xdb, 0x78, 0xa4, 0x9c, 0xf1, 0x70, 0x3c, 0x62, 0x42, 0xc6, 0xd6, 0x26, 0x35,
+	0xa0, 0xbb, 0x0e, 0xa4, 0x38, 0x00, 0x65, 0x40, 0xf7, 0x14, 0x96, 0x29, 0x13, 0x29, 0x4f, 0xd8,
+	0xe2, 0x09, 0x99, 0xbe, 0x2b, 0xe5, 0xbe, 0x8f, 0x13, 0x3e, 0xd2, 0x4e, 0x2c, 0xdb, 0x64, 0x03,
+	0xda, 0xfd, 0x20, 0xf2, 0x7b, 0x23, 0x3e, 0x8e, 0x52, 0xe1, 0xd8, 0x9b, 0xd5, 0xad, 0x16, 0x05,
+	0x44, 0x3d, 0x97, 0x18, 0x77, 0x0d, 0x56, 0xb2, 0xce, 0x74, 0xff, 0xff, 0x6f, 0xc1, 0xf2, 0xf3,
+	0x60, 0x98, 0x78, 0x97, 0xa6, 0x27, 0x57, 0x37, 0x8a, 0x48, 0x79, 0x6c, 0x8c, 0x82, 0x6d, 0xb2,
+	0x0c, 0x95, 0x94, 0x4b, 0x7b, 0xb4, 0x68, 0x25, 0xc5, 0xa3, 0xad, 0xee, 0xcb, 0x8c, 0x48, 0x1e,
+	0x32, 0x4d, 0xaa, 0x21, 0x34, 0x5e, 0x9c, 0xb0, 0x01, 0x8f, 0x27, 0xf2, 0x68, 0x69, 0x52, 0x03,
+	0x92, 0x9f, 0x00, 0x8c, 0xbc, 0xd7, 0xbd, 0x84, 0x8f, 0x23, 0x5f, 0xc8, 0x73, 0xa5, 0x43, 0x5b,
+	0x23, 0xef, 0x35, 0x95, 0x88, 0xa2, 0xd5, 0x5b, 0x25, 0xab, 0x4f, 0xdb, 0x04, 0x66, 0x6c, 0xb2,
+	0x07, 0x2b, 0xd9, 0xfc, 0xb5, 0x53, 0x7f, 0x0c, 0x75, 0xdd, 0x91, 0x4a, 0x30, 0x9c, 0xa2, 0x47,
+	0x1b, 0x66, 0x64, 0xa0, 0x9a, 0xcf, 0xfd, 0x07, 0x0b, 0x96, 0x8a, 0x04, 0xf4, 0x41, 0x49, 0x92,
+	0x66, 0xec, 0xd0, 0x5a, 0x62, 0xb0, 0x7e, 0x90, 0xa4, 0x13, 0x69, 0xc3, 0x2a, 0x55, 0x80, 0xb4,
+	0x18, 0x8b, 0x52, 0x69, 0xc5, 0x2a, 0x95, 0x6d, 0x72, 0x1f, 0x9a, 0xfe, 0x38, 0xf1, 0xe4, 0x26,
+	0x91, 0xc1, 0x68, 0x77, 0xed, 0xff, 0xde, 0x6c, 0x74, 0x30, 0x71, 0xdd, 0xde, 0xd7, 0x04, 0x9a,
+	0xb1, 0xa0, 0xe2, 0xe3, 0x00, 0x73, 0x34, 0xe5, 0x73, 0x0a, 0x70, 0xff, 0xdb, 0x82, 0xe5, 0xc3,
+	0x84, 0xed, 0xf1, 0x78, 0x72, 0xd9, 0xda, 0x12, 0xb0, 0x63, 0x2f, 0x3d, 0xd1, 0x8b, 0x2b, 0xdb,
+	0x88, 0xf3, 0xbd, 0xd4, 0x93, 0xe3, 0x5a, 0xa2, 0xb2, 0xad, 0x56, 0x3c, 0x3a, 0x55, 0x09, 0x05,
+	0x95, 0x6d, 0x34, 0x7e, 0xa2, 0xbc, 0xca, 0xb8, 0xbc, 0x06, 0x71, 0x9d, 0xb5, 0x21, 0xeb, 0x2a,
+	0x77, 0x54, 0x50, 0xe6, 0xbc, 0x8d, 0x82, 0xf3, 0xa2, 0x4f, 0x04, 0x43, 0x26, 0x52, 0xb9, 0xba,
+	0x2d, 0xaa, 0xa1, 0xe9, 0x05, 0x6c, 0xcd, 0x73, 0xea, 0x6c, 0x92, 0xda, 0xa9, 0xff, 0xc7, 0x82,
+	0x95, 0x97, 0x89, 0x17, 0x89, 0x63, 0x96, 0x2c, 0xde, 0x56, 0xb7, 0xc0, 0xee, 0x87, 0xbc, 0xaf,
+	0x0f, 0x92, 0x52, 0x16, 0xb9, 0x1b, 0xf2, 0x3e, 0x95, 0xd4, 0xb9, 0x56, 0xb8, 0x03, 0xb5, 0x20,
+	0xf2, 0xd9, 0x6b, 0xc7, 0x5e, 0x20, 0xaa, 0xc8, 0x17, 0x58, 0xc6, 0xec, 0x9c, 0xfa, 0x9c, 0x2d,
+	0xdd, 0x58, 0xbc, 0xa5, 0x9b, 0x33, 0xb3, 0xff, 0x2f, 0x0b, 0x6c, 0xec, 0x52, 0xee, 0x10, 0xe6,
+	0x07, 0x5e, 0x2f, 0x9d, 0xc4, 0xe6, 0x18, 0x6e, 0x49, 0xcc, 0xcb, 0x49, 0xcc, 0x0a, 0xe6, 0xad,
+	0x94, 0xcc, 0x8b, 0xce, 0x87, 0x27, 0x9e, 0x71, 0x3e, 0x4c, 0xb8, 0xf6, 0xa0, 0xed, 0x45, 0x11,
+	0x4f, 0xa5, 0x6f, 0xa9, 0x38, 0xd2, 0x7e, 0xf0, 0xd3, 0xe9, 0x49, 0x6e, 0x7f, 0x93, 0xf3, 0xa8,
+	0xbc, 0xa3, 0x28, 0xd5, 0xfd, 0x39, 0xac, 0x4e, 0x33, 0xbc, 0x53, 0x7e, 0x41, 0x60, 0x35, 0x5f,
+	0x42, 0xbd, 0xae, 0xcf, 0xe1, 0xc6, 0x63, 0x91, 0x06, 0x23, 0x2f, 0x65, 0x57, 0x8f, 0xe5, 0x85,
+	0xd8, 0x50, 0x29, 0x47, 0xe4, 0x7f, 0xb6, 0xa0, 0x3b, 0x4f, 0x9f, 0x0e, 0x03, 0xd7, 0xa0, 0x92,
+	0x9c, 0x4b, 0x85, 0x55, 0xa5, 0x90, 0x7e, 0x4f, 0x2b, 0xc9, 0x39, 0x9a, 0x52, 0x65, 0xbc, 0x7a,
+	0x1b, 0x6b, 0x88, 0x7c, 0x9a, 0x77, 0xa4, 0x2e, 0x0d, 0xdd, 0xa2, 0xc9, 0xfe, 0x4c, 0x92, 0x4c,
+	0x77, 0x79, 0x80, 0x5a, 0x87, 0x5a, 0xca, 0x53, 0x2f, 0xd4, 0x39, 0x87, 0x02, 0xdc, 0x9f, 0xc1,
+	0x72, 0x59, 0x60, 0x6e, 0x82, 0x65, 0x16, 0xaf, 0x92, 0x2f, 0x5e, 0xf9, 0x98, 0xc9, 0xee, 0x50,
+	0x47, 0xf0, 0x5e, 0x09, 0xab, 0xa7, 0xf8, 0x15, 0xb4, 0x07, 0x39, 0xda, 0xb1, 0x66, 0x87, 0x9d,
+	0x4b, 0xc9, 0xfc, 0xb4, 0xc8, 0xee, 0xfe, 0xa7, 0x05, 0xcb, 0x65, 0xfa, 0x9c, 0x5d, 0xa6, 0x96,
+	0xa5, 0xb2, 0xf8, 0x1a, 0x53, 0x2d, 0x26, 0x87, 0x85, 0xfc, 0xce, 0xfe, 0x43, 0xf2, 0x3b, 0x63,
+	0x91, 0x5a, 0xc1, 0x9d, 0xe7, 0xed, 0xb5, 0x82, 0x53, 0x34, 0xca, 0x4e, 0xf1, 0x11, 0x38, 0x07,
+	0x91, 0x88, 0xd9, 0x20, 0x9d, 0x75, 0xb1, 0xd9, 0x64, 0xe4, 0xb7, 0x16, 0xdc, 0x98, 0xc3, 0xae,
+	0xcd, 0xfb, 0x25, 0x40, 0x6e, 0x2f, 0x9d, 0x1e, 0x5d, 0x64, 0xdd, 0x02, 0x77, 0xe1, 0xd6, 0x53,
+	0xb9, 0xca, 0xad, 0xe7, 0x0e, 0xd4, 0x30, 0x5c, 0x19, 0xd7, 0x9b, 0x13, 0x92, 0x24, 0xd9, 0xfd,
+	0x10, 0xae, 0xab, 0x62, 0xc4, 0x55, 0x66, 0xf7, 0x21, 0x5c, 0x7f, 0xfc, 0x3a, 0xe6, 0xc9, 0x95,
+	0x4c, 0xb1, 0x0d, 0xce, 0x2c, 0xb3, 0x36, 0x84, 0x09, 0xa2, 0x56, 0x1e, 0x44, 0xdd, 0xaf, 0xe1,
+	0xfa, 0xc1, 0xe8, 0x8a, 0xca, 0x33, 0x05, 0x95, 0x82, 0x02, 0x5c, 0xa9, 0xd1, 0x82, 0x0e, 0x67,
+	0x87, 0x77, 0x1b, 0xda, 0xcf, 0x78, 0x5f, 0x5c, 0x96, 0x28, 0x3f, 0x84, 0x25, 0xc5, 0xa6, 0x15,
+	0x7d, 0x00, 0xf6, 0xaf, 0x78, 0xdf, 0x6c, 0x8d, 0x95, 0xa2, 0x59, 0x9f, 0xe1, 0x19, 0x81, 0x44,
+	0x77, 0x02, 0xd5, 0x67, 0xbc, 0xbf, 0x30, 0x02, 0x75, 0xa1, 0x29, 0x06, 0x27, 0xcc, 0x1f, 0x87,
+	0x26, 0xce, 0x65, 0xf0, 0xc2, 0x7b, 0xfb, 0x1d, 0xb0, 0x93, 0x71, 0x16, 0x7c, 0xc9, 0x74, 0xbf,
+	0xe3, 0x88, 0x4a, 0xba, 0xfb, 0x2f, 0x16, 0xd4, 0x15, 0x02, 0xf7, 0x8e, 0xbc, 0xb2, 0x32, 0xdf,
+	0xb1, 0xde, 0x65, 0xef, 0x68, 0x21, 0xf2, 0x25, 0xd4, 0x58, 0xe4, 0xbf, 0xe3, 0xcd, 0x4a, 0x89,
+	0xe0, 0xcd, 0x3e, 0xbf, 0x03, 0x57, 0xe5, 0x1d, 0xb8, 0xc9, 0xf4, 0xfd, 0xd7, 0xfd, 0x14, 0xe0,
+	0x19, 0xef, 0x3f, 0x0d, 0xf0, 0xe8, 0x9b, 0x64, 0x33, 0xb3, 0x2e, 0x99, 0xd9, 0x5d, 0xe8, 0xd0,
+	0x71, 0x84, 0xa8, 0x4b, 0x96, 0xec, 0x77, 0x2d, 0x68, 0xed, 0x15, 0x2e, 0x17, 0xef, 0x52, 0x36,
+	0x71, 0xa0, 0xa1, 0x2f, 0xcb, 0xda, 0xfe, 0x06, 0x24, 0xf7, 0x31, 0x17, 0xe5, 0x03, 0x26, 0x84,
+	0x8e, 0x44, 0xef, 0x15, 0x47, 0x7a, 0xa8, 0x48, 0xd4, 0xf0, 0x90, 0x7b, 0x50, 0xd7, 0x67, 0x74,
+	0x4d, 0xce, 0x6b, 0xad, 0x94, 0x33, 0x22, 0x85, 0x6a, 0x06, 0xbc, 0x33, 0x25, 0x4c, 0xf0, 0x71,
+	0x32, 0x60, 0x2a, 0x31, 0x9a, 0xda, 0xdc, 0xd4, 0x10, 0x69, 0xce, 0x87, 0xc9, 0xca, 0x30, 0x1e,
+	0xab, 0x68, 0x35, 0xb5, 0xbd, 0x9f, 0x1c, 0xbe, 0x12, 0x54, 0x52, 0xc9, 0xd7, 0xd0, 0x14, 0x2c,
+	0x39, 0x0b, 0x06, 0x4c, 0xe5, 0x0a, 0xed, 0x07, 0x1f, 0xcc, 0x0d, 0x1b, 0xdb, 0x47, 0x9a, 0x4b,
+	0x17, 0x0c, 0x8c, 0x10, 0xf9, 0x0a, 0x1a, 0x2a, 0xa0, 0xa8, 0x4c, 0xab, 0xfd, 0xc0, 0x9d, 0x2f,
+	0xbf, 0xa7, 0x98, 0x94, 0xb8, 0x11, 0x51, 0x65, 0x14, 0xcf, 0xe7, 0x51, 0x38, 0x91, 0x35, 0x9c,
+	0x26, 0xcd, 0x60, 0xf2, 0x51, 0x1e, 0x71, 0xdb, 0xb3, 0x2b, 0xaf, 0x0e, 0xbb, 0xfc, 0x54, 0xfc,
+	0x04, 0xea, 0x63, 0xc1, 0x92, 0x08, 0x2b, 0x38, 0x33, 0x25, 0xcd, 0x57, 0x82, 0x25, 0x2f, 0xbc,
+	0x11, 0x13, 0xb1, 0x37, 0x60, 0x54, 0x33, 0x92, 0x8f, 0x71, 0xee, 0x83, 0x71, 0x12, 0xa4, 0x13,
+	0x59, 0xc3, 0x99, 0x2e, 0xfb, 0x68, 0x1a, 0xcd, 0xb8, 0xc8, 0x43, 0x95, 0x9e, 0x79, 0x49, 0xea,
+	0x2c, 0xcf, 0xf6, 0x42, 0x15, 0xe9, 0x90, 0x87, 0xc1, 0x60, 0x42, 0x0d, 0x27, 0x46, 0x22, 0x99,
+	0x61, 0xad, 0xa8, 0x73, 0x18, 0xdb, 0xa5, 0x0d, 0xbe, 0x3a, 0xb5, 0xc1, 0xef, 0x83, 0x1d, 0x44,
+	0x41, 0xaa, 0x6b, 0x38, 0xa5, 0x1e, 0x0e, 0xa2, 0x20, 0xcd, 0x4c, 0x4a, 0x25, 0x1b, 0x26, 0x7c,
+	0x78, 0x65, 0xea, 0xe9, 0x9a, 0xab, 0x2c, 0xdc, 0x50, 0x40, 0xd4, 0x91, 0xc4, 0x60, 0x0d, 0x4e,
+	0x32, 0xe0, 0x55, 0x80, 0x8f, 0x53, 0xe7, 0x3d, 0x79, 0xd2, 0x49, 0xa1, 0x97, 0x0a, 0x45, 0x3e,
+	0x94, 0x45, 0x8e, 0x1e, 0xa2, 0x9c, 0xf5, 0xd9, 0xe3, 0xe0, 0x29, 0xe7, 0xa7, 0xf2, 0x66, 0x85,
+	0x85, 0x03, 0xb2, 0x53, 0x28, 0x4e, 0xfd, 0x78, 0xb3, 0x3a, 0xed, 0xe8, 0xba, 0xc6, 0x53, 0x28,
+	0x4c, 0xdd, 0x87, 0x1a, 0x06, 0x5d, 0xe1, 0x5c, 0x9b, 0x2d, 0x65, 0x1d, 0xf2, 0x24, 0x7d, 0xee,
+	0xc5, 0x71, 0x10, 0x0d, 0xa9, 0xe2, 0x42, 0xdb, 0x64, 0x75, 0xac, 0xeb, 0x32, 0x7d, 0xcd, 0x60,
+	0x5d, 0x9c, 0x74, 0x66, 0x8a, 0x93, 0x5b, 0x50, 0xf3, 0xc2, 0x90, 0x9f, 0x3b, 0x37, 0x36, 0xad,
+	0x69, 0x4f, 0xd1, 0xeb, 0xa1, 0x18, 0xd0, 0x1a, 0x41, 0x34, 0x4c, 0x98, 0x10, 0x3d, 0xbc, 0x7c,
+	0x39, 0x5d, 0x59, 0xa0, 0x6b, 0x6b, 0x1c, 0xc5, 0xc4, 0x69, 0x03, 0xda, 0xac, 0xc0, 0x71, 0x53,
+	0x72, 0x00, 0xcb, 0x18, 0xba, 0x87, 0xd0, 0x29, 0x6d, 0x87, 0x39, 0x69, 0xea, 0xbd, 0x62, 0x9a,
+	0x3a, 0x6d, 0x21, 0x25, 0x5b, 0xc8, 0x5d, 0xbb, 0x2f, 0x60, 0xa9, 0xb8, 0x41, 0xe6, 0x28, 0xdc,
+	0x2a, 0x2b, 0x24, 0x53, 0xbb, 0xec, 0x38, 0x18, 0x16, 0x73, 0xe1, 0x5f, 0x41, 0x5d, 0x4d, 0x9b,
+	0x7c, 0x0c, 0x0d, 0x3d, 0x37, 0x1d, 0x3f, 0xaf, 0xcd, 0xb1, 0xcd, 0x38, 0x64, 0xd4, 0xb0, 0x91,
+	0x6d, 0xa8, 0xab, 0xb9, 0x3a, 0x95, 0x0b, 0x05, 0x34, 0x97, 0xfb, 0x1b, 0x80, 0x1c, 0x2b, 0x3d,
+	0xdb, 0x04, 0x14, 0x4b, 0xad, 0x9e, 0x81, 0xc9, 0x06, 0xd4, 0x06, 0x81, 0x9f, 0x28, 0xc5, 0xad,
+	0xdd, 0xd6, 0xdb, 0x37, 0x1b, 0xb5, 0xbd, 0x83, 0x7d, 0x2a, 0xa8, 0xc2, 0x63, 0xc8, 0x55, 0x9e,
+	0x82, 0x39, 0x49, 0xa7, 0xe0, 0x10, 0xf2, 0x44, 0x19, 0xf0, 0x50, 0x5f, 0x23, 0x33, 0xd8, 0xfd,
+	0x7b, 0x0b, 0xda, 0x05, 0x1f, 0xc2, 0x63, 0xe5, 0x84, 0x8b, 0xb4, 0x87, 0x92, 0xfa, 0x2a, 0xdd,
+	0x44, 0x04, 0xf2, 0x90, 0xdb, 0xb0, 0x9c, 0xd5, 0x94, 0x14, 0x87, 0x7a, 0xa1, 0xe8, 0x64, 0x58,
+	0xc9, 0x56, 0xec, 0xaf, 0x5a, 0xee, 0x8f, 0x7c, 0x00, 0x0d, 0xa9, 0x3f, 0x2b, 0x91, 0xc3, 0xdb,
+	0x37, 0x1b, 0xf5, 0xa7, 0x5c, 0xa4, 0x07, 0x87, 0xb4, 0x8e, 0xa4, 0x83, 0xd8, 0x15, 0xd0, 0xd0,
+	0xbb, 0xe0, 0x1d, 0xaa, 0x9c, 0x04, 0x6c, 0x2f, 0x19, 0xaa, 0xa9, 0xb7, 0xa8, 0x6c, 0xa3, 0x1b,
+	0xb0, 0xe8, 0x4c, 0xd7, 0x65, 0xb0, 0x79, 0x41, 0xb5, 0xe8, 0x53, 0xb0, 0x71, 0x9f, 0x66, 0x7a,
+	0xac, 0x82, 0x1e, 0x07, 0x1a, 0x66, 0xf7, 0xab, 0xcc, 0xdf, 0x80, 0xee, 0x17, 0xd0, 0x29, 0x05,
+	0x95, 0x7c, 0x70, 0xd6, 0xbc, 0xc1, 0x55, 0x72, 0xa5, 0xee, 0x6f, 0xa0, 0x53, 0x8a, 0x78, 0x98,
+	0x99, 0xc4, 0xb2, 0xa5, 0x65, 0x35, 0x84, 0xfb, 0x49, 0x96, 0x62, 0x58, 0x9a, 0x04, 0xfa, 0x4e,
+	0x55, 0xa5, 0x58, 0x9d, 0xa1, 0x0a, 0x83, 0x7d, 0xfa, 0x2c, 0xf4, 0x26, 0xfa, 0x4e, 0xa9, 0x00,
+	0x5c, 0x4a, 0x14, 0x53, 0x14, 0x75, 0xd7, 0x69, 0x8e, 0xbc, 0xd7, 0xfb, 0x08, 0xbb, 0xff, 0x88,
+	0xb5, 0x5f, 0x13, 0x96, 0x1d, 0x68, 0x08, 0x36, 0x18, 0xf0, 0x51, 0xac, 0x7b, 0x36, 0x20, 0xb9,
+	0x0b, 0x2b, 0xba, 0xd9, 0x8b, 0x13, 0x7e, 0x1c, 0xe8, 0x7c, 0x6a, 0x89, 0x2e, 0x6b, 0xf4, 0xa1,
+	0xc2, 0xe2, 0x9a, 0x7b, 0x71, 0xec, 0x25, 0x23, 0x9e, 0x98, 0x35, 0x37, 0x30, 0xb9, 0x07, 0xab,
+	0xa6, 0x9d, 0x69, 0xb1, 0xa5, 0x96, 0x15, 0x83, 0xd7, 0x6a, 0xdc, 0x27, 0xd0, 0x29, 0x9d, 0x35,
+	0xb8, 0x82, 0xe3, 0xc0, 0x14, 0x75, 0xb0, 0x89, 0x98, 0xa1, 0xbe, 0xdf, 0x74, 0x28, 0x36, 0x4b,
+	0x37, 0xea, 0x8e, 0xbe, 0x94, 0x51, 0xa8, 0xab, 0x13, 0x6e, 0x61, 0x7a, 0xb2, 0x09, 0x6d, 0x9f,
+	0x89, 0x34, 0x88, 0xbc, 0x42, 0x61, 0xb4, 0x88, 0xc2, 0x22, 0x5a, 0x72, 0xae, 0x4b, 0x6d, 0x95,
+	0xe4, 0xdc, 0x3d, 0x86, 0xba, 0x8a, 0x14, 0x59, 0xf1, 0xc6, 0x2a, 0x14, 0x6f, 0x30, 0xaf, 0x94,
+	0xa9, 0x83, 0xb9, 0xef, 0x2b, 0xa8, 0xf0, 0x9c, 0x67, 0xf2, 0x4d, 0x09, 0xa1, 0xd1, 0x71, 0xdb,
+	0x60, 0x1d, 0x4a, 0x6d, 0x4a, 0x03, 0xba, 0xff, 0x6a, 0x41, 0x43, 0xc7, 0x38, 0xd9, 0x93, 0xd9,
+	0x8a, 0x55, 0x2a, 0xdb, 0xa8, 0x51, 0x3f, 0x95, 0x29, 0x77, 0xd2, 0x90, 0xb4, 0x55, 0x62, 0xba,
+	0xc1, 0x26, 0x9e, 0x1c, 0xf2, 0x82, 0xa3, 0x13, 0xaa, 0xd2, 0xc9, 0xf1, 0x94, 0x79, 0x61, 0x7a,
+	0x22, 0x33, 0x79, 0xaa, 0xb8, 0x30, 0x03, 0x33, 0xb1, 0xae, 0x36, 0x1b, 0x76, 0x0f, 0x14, 0x29,
+	0x0b, 0x74, 0xee, 0x43, 0x68, 0x68, 0x1c, 0x7a, 0x20, 0xee, 0x5d, 0xb3, 0x6b, 0x14, 0x30, 0xaf,
+	0xc6, 0xe5, 0x72, 0x68, 0x17, 0x7a, 0xce, 0x0e, 0x77, 0xab, 0x7c, 0xb8, 0x07, 0x51, 0xca, 0x92,
+	0x33, 0xfd, 0x04, 0x5a, 0xa5, 0x19, 0x5c, 0xdc, 0x89, 0xd5, 0xd2, 0x4e, 0x54, 0x45, 0x82, 0xf4,
+	0x84, 0xfb, 0xda, 0x9c, 0x1a, 0x72, 0xf7, 0xc1, 0xc6, 0x7c, 0x0d, 0x25, 0x7d, 0x96, 0xc7, 0xd5,
+	0x2a, 0x35, 0x20, 0x71, 0x61, 0x69, 0xe0, 0xc5, 0x5e, 0x3f, 0x08, 0x83, 0x54, 0x6d, 0x30, 0x9c,
+	0x43, 0x09, 0xe7, 0xfe, 0x7b, 0x0d, 0x5a, 0x59, 0x9a, 0x88, 0xa3, 0x1e, 0x60, 0x6e, 0x68, 0xc9,
+	0xe7, 0x38, 0xd9, 0x5e, 0x58, 0xa4, 0x58, 0x87, 0x9a, 0x18, 0xf0, 0xc4, 0x14, 0x7c, 0x14, 0x80,
+	0x4f, 0x1f, 0x11, 0xef, 0x65, 0x5b, 0xc1, 0xa6, 0xf5, 0x88, 0x7f, 0x1b, 0xc8, 0xec, 0x85, 0xe8,
+	0x17, 0xbf, 0x84, 0x61, 0xe0, 0x57, 0xde, 0xa9, 0x6e, 0xd7, 0x6b, 0x8a, 0x42, 0x73, 0x82, 0x8c,
+	0x0d, 0x8a, 0x5d, 0x9c, 0x7b, 0xb1, 0x53, 0xd7, 0xb1, 0x41, 0xa2, 0x8e, 0xce, 0xbd, 0x18, 0x19,
+	0x70, 0x78, 0x2c, 0xed, 0x0d, 0x4c, 0x36, 0xdb, 0xa2, 0xa0, 0x50, 0x7b, 0x38, 0xee, 0x9c, 0x61,
+	0xc4, 0x46, 0xc2, 0x69, 0x16, 0x19, 0x9e, 0xb3, 0x91, 0x5a, 0xc5, 0xc0, 0x57, 0x75, 0x5e, 0x74,
+	0xc1, 0xc0, 0x17, 0x98, 0x05, 0xf4, 0xc3, 0xd3, 0x80, 0xf7, 0xce, 0x59, 0x30, 0x3c, 0x49, 0x65,
+	0xee, 0xd9, 0xa1, 0x6d, 0x89, 0xfb, 0x5e, 0xa2, 0xc8, 0x33, 0x58, 0x2f, 0xb2, 0xf4, 0x8c, 0xf1,
+	0xdb, 0xb3, 0x15, 0x5e, 0x25, 0xb1, 0x2f, 0x19, 0x28, 0x29, 0x28, 0xd9, 0xd7, 0x2b, 0xb4, 0x0b,
+	0x2b, 0x4a, 0xbc, 0x87, 0xd9, 0x6d, 0xaf, 0x1f, 0x9b, 0x77, 0xc6, 0xd2, 0xdd, 0xfe, 0xe5, 0x49,
+	0xc2, 0xd3, 0x34, 0x64, 0x5a, 0x51, 0x47, 0x89, 0x50, 0xe6, 0xf9, 0xbb, 0xb1, 0x20, 0xfb, 0xb0,
+	0xaa, 0x75, 0x9c, 0x27, 0x41, 0xca, 0xa4, 0x92, 0xce, 0xa5, 0x4a, 0x96, 0x95, 0xcc, 0xf7, 0x28,
+	0x52, 0xd6, 0x22, 0x47, 0x12, 0xf0, 0x18, 0xdf, 0x28, 0xaf, 0xa8, 0x05, 0x87, 0x72, 0xc0, 0x63,
+	0x41, 0xbe, 0x85, 0xb5, 0xd2, 0x58, 0xa4, 0x9a, 0x95, 0x4b, 0xd5, 0xac, 0x14, 0x06, 0x23, 0xf5,
+	0x7c, 0x04, 0x8d, 0x44, 0xbe, 0xed, 0x9a, 0x67, 0xce, 0x52, 0x5a, 0x43, 0x25, 0x89, 0x1a, 0x16,
+	0xf7, 0x4b, 0x58, 0x2a, 0x9a, 0x75, 0x51, 0x14, 0xd3, 0x4b, 0xaa, 0x3f, 0x1f, 0x28, 0x08, 0xcb,
+	0x63, 0xe5, 0xc1, 0xcc, 0x95, 0x26, 0x60, 0x27, 0xe6, 0x3b, 0x86, 0x4d, 0x65, 0xdb, 0xdd, 0x87,
+	0xba, 0x1a, 0xc8, 0xdc, 0xbd, 0x4e, 0xc0, 0x3e, 0xf1, 0x12, 0xdf, 0x48, 0x60, 0x1b, 0x71, 0x82,
+	0x1f, 0xab, 0x0d, 0x6e, 0x53, 0xd9, 0x76, 0x39, 0xd4, 0xe4, 0x9d, 0x6e, 0xae, 0x92, 0x45, 0xa1,
+	0x77, 0x2a, 0xc4, 0x57, 0x67, 0x43, 0xbc, 0x03, 0x0d, 0x1e, 0xe7, 0x45, 0xd7, 0x16, 0x35, 0xa0,
+	0x3b, 0x81, 0x86, 0xbe, 0x72, 0xe2, 0x4d, 0x10, 0x6f, 0x3c, 0xfa, 0x8e, 0xbf, 0x3a, 0x7d, 0x31,
+	0xa2, 0x92, 0x3a, 0xef, 0x88, 0x37, 0xf9, 0x47, 0x35, 0xcf, 0x3f, 0xa6, 0x63, 0x8d, 0x3d, 0x27,
+	0xd6, 0xfc, 0x31, 0xd8, 0xa8, 0xf7, 0x2a, 0x67, 0xdf, 0x83, 0xff, 0xed, 0x40, 0xed, 0x9b, 0x21,
+	0x3e, 0x60, 0x3c, 0x82, 0xba, 0xfa, 0x52, 0x43, 0xca, 0xbf, 0x3e, 0x8a, 0xdf, 0x6c, 0xba, 0xd7,
+	0x66, 0x4a, 0x0c, 0x8f, 0xf1, 0xdb, 0x0e, 0x0a, 0xab, 0x22, 0x55, 0x59, 0xb8, 0xf4, 0x8b, 0x66,
+	0xa1, 0xf0, 0x67, 0x50, 0x7d, 0xc2, 0x52, 0x52, 0xca, 0x73, 0xf3, 0x6f, 0x35, 0xdd, 0xeb, 0x33,
+	0xf8, 0xec, 0x23, 0x8d, 0x8d, 0xff, 0x61, 0x48, 0x89, 0xa1, 0xf0, 0x43, 0x66, 0x61, 0x87, 0x5f,
+	0x80, 0x8d, 0x5f, 0x5f, 0xca, 0x82, 0x85, 0xbf, 0x31, 0x5d, 0x67, 0x96, 0xa0, 0xfb, 0x7c, 0x0c,
+	0x4d, 0xf3, 0x5c, 0x4c, 0x6e, 0x96, 0x36, 0x4b, 0xf9, 0xbd, 0xb9, 0xfb, 0xfe, 0x7c, 0x62, 0xf6,
+	0xd9, 0xa6, 0x26, 0x1f, 0x8b, 0x49, 0xa9, 0xa7, 0xe2, 0xfb, 0xf1, 0xc2, 0xc1, 0x7f, 0x0e, 0xb6,
+	0xbc, 0x06, 0x96, 0x7f, 0x24, 0xe4, 0x2f, 0xca, 0x0b, 0x05, 0xbf, 0x86, 0xba, 0x7a, 0x0b, 0x2e,
+	0xaf, 0x51, 0xe9, 0x95, 0xb9, 0xdb, 0x9d, 0x47, 0xd2, 0x83, 0xfe, 0x06, 0x5a, 0xd9, 0x6b, 0x2f,
+	0x29, 0xcd, 0x6f, 0xfa, 0x11, 0xf8, 0xa2, 0xc1, 0x23, 0x6f, 0x79, 0xf0, 0x85, 0xc7, 0xe1, 0x85,
+	0x82, 0xbf, 0x00, 0xc8, 0x8b, 0x86, 0xe4, 0x27, 0xf3, 0x4b, 0xb2, 0x46, 0xc9, 0x1f, 0x2d, 0x22,
+	0xeb, 0x89, 0xec, 0x42, 0x43, 0xbf, 0xaa, 0x92, 0xee, 0x74, 0x01, 0x21, 0x7f, 0xd7, 0xed, 0xde,
+	0x9c, 0x4b, 0xcb, 0x75, 0xe8, 0xf7, 0xc3, 0xb2, 0x8e, 0xf2, 0xd3, 0x6c, 0xf7, 0xe6, 0x5c, 0x9a,
+	0xd6, 0xb1, 0x0f, 0x0d, 0xfd, 0x10, 0x56, 0xd6, 0x51, 0x7e, 0x02, 0xec, 0xde, 0x9c, 0x4b, 0x53,
+	0x3a, 0xb6, 0x2c, 0xf2, 0x04, 0x9a, 0xe6, 0xdd, 0xa5, 0xec, 0x92, 0x53, 0x0f, 0x6a, 0xdd, 0xf7,
+	0xe7, 0x13, 0x33, 0x45, 0x03, 0x20, 0xb3, 0x8f, 0x2b, 0xe4, 0x76, 0x51, 0x6a, 0xe1, 0x63, 0x4e,
+	0xf7, 0xce, 0x65, 0x6c, 0x7a, 0xce, 0x2f, 0xa0, 0x9d, 0x63, 0x05, 0x59, 0xb0, 0x54, 0xa6, 0xea,
+	0xdb, 0xdd, 0x58, 0x48, 0xd7, 0xfa, 0xfe, 0x1a, 0xd6, 0x66, 0xca, 0xf9, 0xe4, 0x56, 0x39, 0xef,
+	0x9c, 0xff, 0x38, 0xd0, 0xbd, 0x7d, 0x09, 0x97, 0xee, 0xe1, 0x97, 0xb0, 0x3a, 0x5d, 0x80, 0x27,
+	0x1f, 0xcc, 0x46, 0xb9, 0x59, 0xfd, 0x8b, 0x7c, 0xb9, 0x07, 0xab, 0xd3, 0x75, 0xf7, 0xb2, 0xc2,
+	0x05, 0x25, 0xfc, 0xee, 0xad, 0x8b, 0x99, 0xd4, 0x78, 0x3f, 0xb6, 0xb0, 0x83, 0x83, 0xd1, 0x45,
+	0x1d, 0x1c, 0x8c, 0xae, 0xd0, 0xc1, 0xa2, 0x52, 0xfd, 0x96, 0x45, 0xbe, 0x82, 0x9a, 0xfc, 0xe0,
+	0x57, 0x0e, 0x5f, 0xc5, 0x3f, 0x80, 0xdd, 0x1b, 0x73, 0x28, 0x59, 0xf0, 0xb3, 0xb1, 0x62, 0x5f,
+	0x0e, 0x02, 0x85, 0x52, 0x7f, 0xd7, 0x99, 0x25, 0x68, 0xd1, 0x47, 0x50, 0x57, 0x25, 0xe6, 0x72,
+	0x0c, 0x2b, 0x95, 0x9d, 0x17, 0xd9, 0x7d, 0xf7, 0xde, 0x5f, 0xdc, 0xbd, 0xca, 0x3f, 0xd6, 0x47,
+	0x67, 0x9f, 0xfc, 0xf9, 0x8f, 0xfa, 0x75, 0x29, 0xfc, 0xf0, 0xf7, 0x03, 0x00, 0x26, 0x2b, 0x2b,
+	0x4b, 0xfb, 0x2a, 0x00, 0x00,
 }
@@ -15,25 +15,86 @@ const (
 	// configuration keys
 	PlainRemotesKey = "io.boss.agent.plain-remotes"
 	VolumeRootKey   = "io.boss.agent.volume-root"
+	// container types
+	ServiceType = ""
+	JobType     = "job"
+	// update actions
+	UpdateActionNone     = "none"
+	UpdateActionLive     = "live"
+	UpdateActionReload   = "reload"
+	UpdateActionRestart  = "restart"
+	UpdateActionRevision = "revision"
 )
 
 func StatePath(id string) string {
 	return filepath.Join(state, id)
 }
 
+// StoreAddressPath is the file where the agent publishes the address of the master store
+func StoreAddressPath() string {
+	return filepath.Join(state, "store")
+}
+
 // Register is an object that registers and manages service information in its backend
 type Register interface {
-	Register(id, name, ip string, s *Service) error
+	// Register registers the service at each of the container's addresses
+	Register(id, name string, ips []string, s *Service) error
 	Deregister(id, name string) error
 	EnableMaintainance(id, name, msg string) error
 	DisableMaintainance(id, name string) error
+	// Resolve returns the addresses of all instances of the service
+	Resolve(name string) ([]string, error)
+	// Registrations returns the services of containers registered by the node
+	Registrations() ([]*Registration, error)
+}
+
+// Registration is a container's service held by a register
+type Registration struct {
+	ID          string
+	Name        string
+	IPs         []string
+	Maintenance bool
+	// Reason is why the service is in maintenance
+	Reason string
+}
+
+const (
+	// MaintenanceCreated is the reason of a service registered before its container is started
+	MaintenanceCreated = "created"
+	// MaintenanceExited is the reason of a service whose container's task exited
+	MaintenanceExited = "task exited"
+)
+
+// IPAM reserves container addresses on networks across the cluster
+type IPAM interface {
+	// Reserve reserves the ip for the container or an address from the subnet if ip is empty.
+	// The excluded addresses are never handed out.
+	Reserve(network, subnet, id, ip string, exclude ...string) (string, error)
+	// Move takes over the address the container holds on the node it is migrated from
+	// returning an empty ip when it has none
+	Move(network, from, id string) (string, error)
+	// Release releases the container's address on the network
+	Release(network, id string) error
 }
 
 type Network interface {
-	Create(context.Context, containerd.Container) (string, error)
+	// Create returns the addresses of the container on its primary network with the container's ip first
+	Create(context.Context, containerd.Container) ([]string, error)
 	Remove(context.Context, containerd.Container) error
 }
 
+// NetworkNames returns the cni networks the container is attached to with the first as the primary
+func NetworkNames(c *Container) []string {
+	if len(c.Networks) > 0 {
+		return c.Networks
+	}
+	switch c.Network {
+	case "", "none", "host":
+		return nil
+	}
+	return []string{c.Network}
+}
+
 func NetworkPath(id string) string {
 	return filepath.Join(StatePath(id), "net")
 }
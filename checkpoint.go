@@ -1,12 +1,35 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
 	"github.com/crosbymichael/boss/api/v1"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
 var checkpointCommand = cli.Command{
 	Name:  "checkpoint",
+	Usage: "manage container checkpoints",
+	Subcommands: []cli.Command{
+		checkpointCreateCommand,
+		checkpointListCommand,
+		checkpointInspectCommand,
+		checkpointDeleteCommand,
+		checkpointExportCommand,
+		checkpointImportCommand,
+	},
+}
+
+var checkpointCreateCommand = cli.Command{
+	Name:  "create",
 	Usage: "checkpoint a container",
 	Flags: []cli.Flag{
 		cli.BoolFlag{
@@ -25,6 +48,10 @@ var checkpointCommand = cli.Command{
 			Name:  "push",
 			Usage: "push the successful checkpoint",
 		},
+		cli.BoolFlag{
+			Name:  "volumes",
+			Usage: "include the container's volumes and bind mounts",
+		},
 	},
 	Action: func(clix *cli.Context) error {
 		ctx := Context()
@@ -33,12 +60,21 @@ var checkpointCommand = cli.Command{
 			return err
 		}
 		defer agent.Close()
-		ref := clix.String("ref")
+		var (
+			id  = clix.Args().First()
+			ref = clix.String("ref")
+		)
+		if clix.Bool("volumes") {
+			if err := printEstimate(ctx, agent, id, clix.Bool("live")); err != nil {
+				return err
+			}
+		}
 		if _, err := agent.Checkpoint(ctx, &v1.CheckpointRequest{
-			ID:   clix.Args().First(),
-			Ref:  ref,
-			Live: clix.Bool("live"),
-			Exit: clix.Bool("exit"),
+			ID:      id,
+			Ref:     ref,
+			Live:    clix.Bool("live"),
+			Exit:    clix.Bool("exit"),
+			Volumes: clix.Bool("volumes"),
 		}); err != nil {
 			return err
 		}
@@ -51,3 +87,203 @@ var checkpointCommand = cli.Command{
 		return nil
 	},
 }
+
+// printEstimate shows the size of a checkpoint with volumes before it is written
+func printEstimate(ctx context.Context, agent v1.AgentClient, id string, live bool) error {
+	resp, err := agent.EstimateCheckpoint(ctx, &v1.EstimateCheckpointRequest{
+		ID:      id,
+		Volumes: true,
+	})
+	if err != nil {
+		return err
+	}
+	total := resp.Total
+	w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+	const tfmt = "%s\t%s\n"
+	fmt.Fprint(w, "LAYER\tSIZE\n")
+	fmt.Fprintf(w, tfmt, "rw", units.HumanSize(float64(resp.RW)))
+	if live {
+		fmt.Fprintf(w, tfmt, "memory", units.HumanSize(float64(resp.Memory)))
+	} else {
+		total -= resp.Memory
+	}
+	for _, v := range resp.Volumes {
+		fmt.Fprintf(w, tfmt, v.Name, units.HumanSize(float64(v.Size_)))
+	}
+	fmt.Fprintf(w, tfmt, "total", units.HumanSize(float64(total)))
+	return w.Flush()
+}
+
+var checkpointListCommand = cli.Command{
+	Name:  "ls",
+	Usage: "list the checkpoints on the node",
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.Checkpoints(ctx, &v1.CheckpointsRequest{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%t\t%t\n"
+		fmt.Fprint(w, "REF\tID\tIMAGE\tCREATED\tSIZE\tLIVE\tVOLUMES\n")
+		for _, c := range resp.Checkpoints {
+			fmt.Fprintf(w, tfmt,
+				c.Ref,
+				c.ID,
+				c.Image,
+				units.HumanDuration(time.Since(c.Created))+" ago",
+				units.HumanSize(float64(c.Size_)),
+				c.Live,
+				c.Volumes,
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var checkpointInspectCommand = cli.Command{
+	Name:  "inspect",
+	Usage: "inspect a checkpoint's container config and blobs",
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.InspectCheckpoint(ctx, &v1.InspectCheckpointRequest{
+			Ref: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(os.Stdout).Encode(resp)
+	},
+}
+
+var checkpointDeleteCommand = cli.Command{
+	Name:  "rm",
+	Usage: "delete checkpoints",
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		for _, ref := range clix.Args() {
+			if _, err := agent.DeleteCheckpoint(ctx, &v1.DeleteCheckpointRequest{
+				Ref: ref,
+			}); err != nil {
+				return errors.Wrapf(err, "delete %s", ref)
+			}
+		}
+		return nil
+	},
+}
+
+var checkpointExportCommand = cli.Command{
+	Name:  "export",
+	Usage: "export a checkpoint to an oci image layout tar",
+	Action: func(clix *cli.Context) error {
+		var (
+			ctx  = Context()
+			ref  = clix.Args().First()
+			path = clix.Args().Get(1)
+		)
+		if path == "" {
+			return errors.New("export path is required")
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		stream, err := agent.ExportCheckpoint(ctx, &v1.ExportCheckpointRequest{
+			Ref: ref,
+		})
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return f.Sync()
+				}
+				os.Remove(path)
+				return err
+			}
+			if _, err := f.Write(resp.Data); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+var checkpointImportCommand = cli.Command{
+	Name:  "import",
+	Usage: "import a checkpoint from an oci image layout tar",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "ref",
+			Usage: "ref name of the imported checkpoint instead of the name in the archive",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		f, err := os.Open(clix.Args().First())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		stream, err := agent.ImportCheckpoint(ctx)
+		if err != nil {
+			return err
+		}
+		var (
+			buf = make([]byte, 1<<20)
+			req = &v1.ImportCheckpointRequest{
+				Ref: clix.String("ref"),
+			}
+		)
+		for {
+			n, err := f.Read(buf)
+			if n > 0 {
+				req.Data = buf[:n]
+				if err := stream.Send(req); err != nil {
+					return err
+				}
+				req = &v1.ImportCheckpointRequest{}
+			}
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				stream.CloseSend()
+				return err
+			}
+		}
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		fmt.Println(resp.Ref)
+		return nil
+	},
+}
@@ -1,41 +1,105 @@
 package cmd
 
-import "github.com/crosbymichael/boss/api/v1"
+import (
+	"io/ioutil"
+	"math"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/systemd"
+	"github.com/pkg/errors"
+)
 
 const Version = "v1"
 
 type Container struct {
-	ConfigVersion string             `toml:"config_version"`
-	ID            string             `toml:"id"`
-	Image         string             `toml:"image"`
-	Resources     *Resources         `toml:"resources"`
-	GPUs          *GPUs              `toml:"gpus"`
-	Mounts        []Mount            `toml:"mounts"`
-	Env           []string           `toml:"env"`
-	Args          []string           `toml:"args"`
-	UID           *int               `toml:"uid"`
-	GID           *int               `toml:"gid"`
-	Network       string             `toml:"network"`
-	Services      map[string]Service `toml:"services"`
-	Configs       map[string]File    `toml:"configs"`
-	Readonly      bool               `toml:"readonly"`
-	Capabilities  []string           `toml:"caps"`
-	Volumes       map[string]Volume  `toml:"volumes"`
-}
-
-func (c *Container) Proto() *v1.Container {
+	ConfigVersion string `toml:"config_version"`
+	ID            string `toml:"id"`
+	// Type is service or job, jobs run to completion
+	Type string `toml:"type"`
+	// Schedule is a cron expression for when a job is run
+	Schedule     string             `toml:"schedule"`
+	Image        string             `toml:"image"`
+	Resources    *Resources         `toml:"resources"`
+	GPUs         *GPUs              `toml:"gpus"`
+	Mounts       []Mount            `toml:"mounts"`
+	Env          []string           `toml:"env"`
+	Args         []string           `toml:"args"`
+	UID          *int               `toml:"uid"`
+	GID          *int               `toml:"gid"`
+	Network      string             `toml:"network"`
+	Services     map[string]Service `toml:"services"`
+	Configs      map[string]File    `toml:"configs"`
+	Readonly     bool               `toml:"readonly"`
+	Capabilities []string           `toml:"caps"`
+	Volumes      map[string]Volume  `toml:"volumes"`
+	Init         []Init             `toml:"init"`
+	UserNS       *UserNS            `toml:"userns"`
+	Security     *Security          `toml:"security"`
+	// Restart is the restart policy: always, on-failure, or never
+	Restart string `toml:"restart"`
+	// MaxRetries is the number of consecutive failed restarts before giving up
+	MaxRetries int64 `toml:"max_retries"`
+	// RestartDelay is the delay in seconds before a restart, doubled after each failure
+	RestartDelay int64 `toml:"restart_delay"`
+	// MaxRestartDelay caps the restart delay in seconds
+	MaxRestartDelay int64 `toml:"max_restart_delay"`
+	// StopSignal is sent to the container's process to stop it, defaults to SIGTERM
+	StopSignal string `toml:"stop_signal"`
+	// StopTimeout is the time in seconds to wait for the process to exit before it is killed
+	StopTimeout int64 `toml:"stop_timeout"`
+	// PreStop hooks are run in the container before it is signaled to stop
+	PreStop []Hook `toml:"pre_stop"`
+	// Sidecars run alongside the container in its network namespace
+	Sidecars map[string]Sidecar `toml:"sidecars"`
+	// Networks are the named networks the container is attached to, the first is its primary network
+	Networks []string `toml:"networks"`
+	// Ports published on the host as [host_ip:]host_port:container_port[/protocol], ipv6 host ips are in brackets
+	Ports []string `toml:"ports"`
+	// IP pins the container's address on its primary network when it uses the boss ipam
+	IP string `toml:"ip"`
+	// Allow restricts the container's traffic to the allowed sources and destinations
+	Allow *Policy `toml:"allow"`
+	// IngressRate limits the traffic received by the container, such as 100mbit
+	IngressRate string `toml:"ingress_rate"`
+	// EgressRate limits the traffic sent by the container
+	EgressRate string `toml:"egress_rate"`
+
+	// dir is the directory of the config file, relative profile paths are resolved from it
+	dir string
+}
+
+// Load decodes the container's config file
+func Load(path string) (*Container, error) {
+	var c Container
+	if _, err := toml.DecodeFile(path, &c); err != nil {
+		return nil, err
+	}
+	c.dir = filepath.Dir(path)
+	return &c, nil
+}
+
+func (c *Container) Proto() (*v1.Container, error) {
 	container := &v1.Container{
-		ID:      c.ID,
-		Image:   c.Image,
-		Network: c.Network,
+		ID:       c.ID,
+		Image:    c.Image,
+		Network:  c.Network,
+		Schedule: c.Schedule,
 		Process: &v1.Process{
 			Args:         c.Args,
 			Env:          c.Env,
 			Capabilities: c.Capabilities,
 		},
-		Readonly: c.Readonly,
-		Services: make(map[string]*v1.Service),
-		Configs:  make(map[string]*v1.Config),
+		Readonly:    c.Readonly,
+		StopSignal:  c.StopSignal,
+		StopTimeout: c.StopTimeout,
+		Services:    make(map[string]*v1.Service),
+		Configs:     make(map[string]*v1.Config),
 	}
 	for _, m := range c.Mounts {
 		container.Mounts = append(container.Mounts, &v1.Mount{
@@ -46,12 +110,7 @@ func (c *Container) Proto() *v1.Container {
 		})
 	}
 	if c.Resources != nil {
-		container.Resources = &v1.Resources{
-			Cpus:   c.Resources.CPU,
-			Memory: c.Resources.Memory,
-			Score:  c.Resources.Score,
-			NoFile: c.Resources.NoFile,
-		}
+		container.Resources = c.Resources.proto()
 	}
 	if c.GPUs != nil {
 		container.Gpus = &v1.GPUs{
@@ -75,6 +134,12 @@ func (c *Container) Proto() *v1.Container {
 			Labels: s.Labels,
 			Url:    s.URL,
 		}
+		if len(s.IngressHosts) > 0 || s.IngressPath != "" {
+			container.Services[name].Ingress = &v1.Ingress{
+				Hosts: s.IngressHosts,
+				Path:  s.IngressPath,
+			}
+		}
 		if s.CheckType != "" {
 			container.Services[name].Check = &v1.HealthCheck{
 				Type:     string(s.CheckType),
@@ -99,7 +164,133 @@ func (c *Container) Proto() *v1.Container {
 			Rw:          vol.RW,
 		})
 	}
-	return container
+	for _, i := range c.Init {
+		if i.Image == "" {
+			return nil, errors.New("init image is required")
+		}
+		container.Init = append(container.Init, &v1.InitContainer{
+			Image: i.Image,
+			Args:  i.Args,
+		})
+	}
+	for name, sc := range c.Sidecars {
+		image := sc.Image
+		if image == "" {
+			image = c.Image
+		}
+		container.Sidecars = append(container.Sidecars, &v1.Sidecar{
+			Name:    name,
+			Image:   image,
+			Args:    sc.Args,
+			Env:     sc.Env,
+			Volumes: sc.Volumes,
+		})
+	}
+	// keep a stable order so that updates can compare configs
+	sort.Slice(container.Sidecars, func(i, j int) bool {
+		return container.Sidecars[i].Name < container.Sidecars[j].Name
+	})
+	if len(c.Networks) > 0 {
+		if c.Network != "" {
+			return nil, errors.New("network and networks cannot both be set")
+		}
+		for _, n := range c.Networks {
+			switch n {
+			case "", "none", "host":
+				return nil, errors.Errorf("invalid network %q in networks", n)
+			}
+		}
+		container.Networks = c.Networks
+	}
+	if c.IP != "" {
+		ip := net.ParseIP(c.IP)
+		if ip == nil || ip.To4() == nil {
+			return nil, errors.Errorf("invalid ipv4 address %q", c.IP)
+		}
+		container.IP = ip.String()
+	}
+	// sidecars join the network namespace that cni sets up for the container
+	if len(container.Sidecars) > 0 && len(v1.NetworkNames(container)) == 0 {
+		return nil, errors.New("sidecars require the container to be attached to a cni network")
+	}
+	if c.Allow != nil {
+		// policies are applied in the network namespace that cni sets up
+		if len(v1.NetworkNames(container)) == 0 {
+			network := c.Network
+			if network == "" {
+				network = "none"
+			}
+			return nil, errors.Errorf("allow cannot be used with the %s network", network)
+		}
+		p, err := c.Allow.proto()
+		if err != nil {
+			return nil, err
+		}
+		container.Allow = p
+	}
+	ingress, err := parseRate(c.IngressRate)
+	if err != nil {
+		return nil, errors.Wrap(err, "ingress_rate")
+	}
+	egress, err := parseRate(c.EgressRate)
+	if err != nil {
+		return nil, errors.Wrap(err, "egress_rate")
+	}
+	container.IngressRate = ingress
+	container.EgressRate = egress
+	for _, p := range c.Ports {
+		m, err := parsePort(p)
+		if err != nil {
+			return nil, err
+		}
+		container.Ports = append(container.Ports, m)
+	}
+	for _, h := range c.PreStop {
+		if len(h.Args) == 0 {
+			return nil, errors.New("pre_stop args are required")
+		}
+		container.PreStop = append(container.PreStop, &v1.Hook{
+			Args:    h.Args,
+			Timeout: h.Timeout,
+		})
+	}
+	if c.UserNS != nil {
+		container.Userns = &v1.UserNamespace{
+			Uid:   c.UserNS.UID,
+			Gid:   c.UserNS.GID,
+			Size_: c.UserNS.Size,
+		}
+	}
+	switch c.Type {
+	case "", "service":
+		container.Type = v1.ServiceType
+	case v1.JobType:
+		container.Type = v1.JobType
+	default:
+		return nil, errors.Errorf("invalid container type %q", c.Type)
+	}
+	if c.Schedule != "" && container.Type != v1.JobType {
+		return nil, errors.New("schedule is only supported for jobs")
+	}
+	if c.Restart != "" || c.MaxRetries != 0 || c.RestartDelay != 0 || c.MaxRestartDelay != 0 {
+		container.Restart = &v1.RestartPolicy{
+			Policy:     c.Restart,
+			MaxRetries: c.MaxRetries,
+			Delay:      c.RestartDelay,
+			MaxDelay:   c.MaxRestartDelay,
+		}
+		if err := systemd.ValidateRestartPolicy(container.Restart); err != nil {
+			return nil, err
+		}
+	}
+	if c.Security != nil {
+		security, err := c.Security.proto(c.dir)
+		if err != nil {
+			return nil, err
+		}
+		container.Security = security
+	}
+	return container, nil
 }
 
 type File struct {
@@ -118,6 +309,10 @@ type Service struct {
 	CheckInterval int64     `toml:"check_interval"`
 	CheckTimeout  int64     `toml:"check_timeout"`
 	CheckMethod   string    `toml:"check_method"`
+	// IngressHosts are the host names routed to the service by the node's ingress
+	IngressHosts []string `toml:"ingress_hosts"`
+	// IngressPath is the path prefix routed to the service by the node's ingress
+	IngressPath string `toml:"ingress_path"`
 }
 
 type CheckType string
@@ -133,6 +328,79 @@ type Resources struct {
 	Memory int64   `toml:"memory"`
 	Score  int64   `toml:"score"`
 	NoFile uint64  `toml:"no_file"`
+	// MemoryReservation is the soft memory limit in MB
+	MemoryReservation int64 `toml:"memory_reservation"`
+	// MemorySwap is the memory plus swap limit in MB
+	MemorySwap         int64            `toml:"memory_swap"`
+	CpusetCpus         string           `toml:"cpuset_cpus"`
+	CpusetMems         string           `toml:"cpuset_mems"`
+	Pids               int64            `toml:"pids"`
+	BlkioWeight        uint32           `toml:"blkio_weight"`
+	BlkioWeightDevices []WeightDevice   `toml:"blkio_weight_devices"`
+	DeviceReadBps      []ThrottleDevice `toml:"device_read_bps"`
+	DeviceWriteBps     []ThrottleDevice `toml:"device_write_bps"`
+	DeviceReadIOps     []ThrottleDevice `toml:"device_read_iops"`
+	DeviceWriteIOps    []ThrottleDevice `toml:"device_write_iops"`
+	Rlimits            []Rlimit         `toml:"rlimits"`
+}
+
+func (r *Resources) proto() *v1.Resources {
+	resources := &v1.Resources{
+		Cpus:              r.CPU,
+		Memory:            r.Memory,
+		Score:             r.Score,
+		NoFile:            r.NoFile,
+		MemoryReservation: r.MemoryReservation,
+		MemorySwap:        r.MemorySwap,
+		CpusetCpus:        r.CpusetCpus,
+		CpusetMems:        r.CpusetMems,
+		Pids:              r.Pids,
+		BlkioWeight:       r.BlkioWeight,
+		DeviceReadBps:     throttleDevices(r.DeviceReadBps),
+		DeviceWriteBps:    throttleDevices(r.DeviceWriteBps),
+		DeviceReadIops:    throttleDevices(r.DeviceReadIOps),
+		DeviceWriteIops:   throttleDevices(r.DeviceWriteIOps),
+	}
+	for _, d := range r.BlkioWeightDevices {
+		resources.BlkioWeightDevices = append(resources.BlkioWeightDevices, &v1.WeightDevice{
+			Path:   d.Path,
+			Weight: d.Weight,
+		})
+	}
+	for _, l := range r.Rlimits {
+		resources.Rlimits = append(resources.Rlimits, &v1.Rlimit{
+			Type: l.Type,
+			Hard: l.Hard,
+			Soft: l.Soft,
+		})
+	}
+	return resources
+}
+
+func throttleDevices(devices []ThrottleDevice) (o []*v1.ThrottleDevice) {
+	for _, d := range devices {
+		o = append(o, &v1.ThrottleDevice{
+			Path: d.Path,
+			Rate: d.Rate,
+		})
+	}
+	return o
+}
+
+type WeightDevice struct {
+	Path   string `toml:"path"`
+	Weight uint32 `toml:"weight"`
+}
+
+type ThrottleDevice struct {
+	Path string `toml:"path"`
+	Rate uint64 `toml:"rate"`
+}
+
+type Rlimit struct {
+	Type string `toml:"type"`
+	Hard uint64 `toml:"hard"`
+	Soft uint64 `toml:"soft"`
 }
 
 type GPUs struct {
@@ -147,7 +415,234 @@ type Mount struct {
 	Options     []string `toml:"options"`
 }
 
+// Init is run to completion before the container's process is started
+type Init struct {
+	Image string   `toml:"image"`
+	Args  []string `toml:"args"`
+}
+
+// Hook is a command run in the container
+type Hook struct {
+	Args []string `toml:"args"`
+	// Timeout in seconds for the command to complete
+	Timeout int64 `toml:"timeout"`
+}
+
+// Policy is the traffic allowed to and from the container.
+// Traffic in a direction without rules is not restricted.
+type Policy struct {
+	Ingress []PolicyRule `toml:"ingress"`
+	Egress  []PolicyRule `toml:"egress"`
+}
+
+// PolicyRule allows traffic from the services and cidrs or to the cidrs
+// on the ports, any address is matched when none are set
+type PolicyRule struct {
+	Services []string `toml:"services"`
+	CIDRs    []string `toml:"cidrs"`
+	Ports    []uint32 `toml:"ports"`
+	// Protocol is tcp or udp, ports are matched for both when empty
+	Protocol string `toml:"protocol"`
+}
+
+// Sidecar is an additional process that shares the container's network namespace
+type Sidecar struct {
+	// Image defaults to the container's image
+	Image string   `toml:"image"`
+	Args  []string `toml:"args"`
+	Env   []string `toml:"env"`
+	// Volumes shares the container's mounts and volumes with the sidecar
+	Volumes bool `toml:"volumes"`
+}
+
 type Volume struct {
 	Destination string `toml:"destination"`
 	RW          bool   `toml:"rw"`
 }
+
+// UserNS maps the container's root user to a subordinate id range on the host
+type UserNS struct {
+	UID  uint32 `toml:"uid"`
+	GID  uint32 `toml:"gid"`
+	Size uint32 `toml:"size"`
+}
+
+// Security selects the seccomp and apparmor profiles for the container
+type Security struct {
+	// Seccomp is "default", "unconfined", or the path to a json profile
+	Seccomp string `toml:"seccomp"`
+	// Apparmor is "default", "unconfined", or the name of a profile
+	Apparmor string `toml:"apparmor"`
+	// ApparmorProfile is the path to a profile to load under the Apparmor name
+	ApparmorProfile string `toml:"apparmor_profile"`
+}
+
+// proto reads any profile files so that they are stored with the container's config,
+// relative paths are resolved from the config file's directory
+func (s *Security) proto(dir string) (*v1.Security, error) {
+	security := &v1.Security{
+		Seccomp:  s.Seccomp,
+		Apparmor: s.Apparmor,
+	}
+	switch s.Seccomp {
+	case "", "default", "unconfined":
+	default:
+		data, err := ioutil.ReadFile(configPath(dir, s.Seccomp))
+		if err != nil {
+			return nil, err
+		}
+		security.SeccompProfile = data
+	}
+	if s.ApparmorProfile != "" {
+		data, err := ioutil.ReadFile(configPath(dir, s.ApparmorProfile))
+		if err != nil {
+			return nil, err
+		}
+		security.ApparmorProfile = data
+	}
+	return security, nil
+}
+
+func configPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (p *Policy) proto() (*v1.Policy, error) {
+	var (
+		policy v1.Policy
+		err    error
+	)
+	if policy.Ingress, err = policyRules(p.Ingress); err != nil {
+		return nil, errors.Wrap(err, "allow ingress")
+	}
+	if policy.Egress, err = policyRules(p.Egress); err != nil {
+		return nil, errors.Wrap(err, "allow egress")
+	}
+	return &policy, nil
+}
+
+func policyRules(rules []PolicyRule) (o []*v1.PolicyRule, _ error) {
+	for _, r := range rules {
+		switch r.Protocol {
+		case "", "tcp", "udp":
+		default:
+			return nil, errors.Errorf("invalid protocol %q", r.Protocol)
+		}
+		if r.Protocol != "" && len(r.Ports) == 0 {
+			return nil, errors.Errorf("protocol %s requires ports", r.Protocol)
+		}
+		var cidrs []string
+		for _, c := range r.CIDRs {
+			// a single address is allowed as a cidr
+			if ip := net.ParseIP(c); ip != nil {
+				cidrs = append(cidrs, ip.String())
+				continue
+			}
+			_, n, err := net.ParseCIDR(c)
+			if err != nil {
+				return nil, errors.Errorf("invalid cidr %q", c)
+			}
+			cidrs = append(cidrs, n.String())
+		}
+		for _, port := range r.Ports {
+			if port == 0 || port > 65535 {
+				return nil, errors.Errorf("invalid port %d", port)
+			}
+		}
+		o = append(o, &v1.PolicyRule{
+			Services: r.Services,
+			CIDRs:    cidrs,
+			Ports:    r.Ports,
+			Protocol: r.Protocol,
+		})
+	}
+	return o, nil
+}
+
+var rateUnits = []struct {
+	suffix string
+	bits   uint64
+}{
+	{"gbit", 1000 * 1000 * 1000},
+	{"mbit", 1000 * 1000},
+	{"kbit", 1000},
+	{"bit", 1},
+}
+
+// parseRate parses a rate in bits per second with an optional kbit, mbit, or gbit unit
+func parseRate(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, unit := strings.ToLower(s), uint64(1)
+	for _, u := range rateUnits {
+		if strings.HasSuffix(v, u.suffix) {
+			v, unit = strings.TrimSuffix(v, u.suffix), u.bits
+			break
+		}
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+	if err != nil || n == 0 {
+		return 0, errors.Errorf("invalid rate %q", s)
+	}
+	if n > math.MaxUint64/unit {
+		return 0, errors.Errorf("rate %q is too large", s)
+	}
+	return n * unit, nil
+}
+
+// parsePort parses a port mapping in the form [host_ip:]host_port:container_port[/protocol]
+// with ipv6 host ips in brackets
+func parsePort(s string) (*v1.PortMapping, error) {
+	m := &v1.PortMapping{
+		Protocol: "tcp",
+	}
+	spec := s
+	if i := strings.LastIndex(spec, "/"); i != -1 {
+		m.Protocol = strings.ToLower(spec[i+1:])
+		spec = spec[:i]
+	}
+	switch m.Protocol {
+	case "tcp", "udp", "sctp":
+	default:
+		return nil, errors.Errorf("invalid protocol in port %q", s)
+	}
+	var parts []string
+	if strings.HasPrefix(spec, "[") {
+		// ipv6 host ips are bracketed, [::1]:80:80
+		i := strings.LastIndex(spec, ":")
+		ip, port, err := net.SplitHostPort(spec[:i])
+		if err != nil || net.ParseIP(ip) == nil {
+			return nil, errors.Errorf("invalid host ip in port %q", s)
+		}
+		m.HostIP = ip
+		parts = []string{port, spec[i+1:]}
+	} else {
+		parts = strings.Split(spec, ":")
+		switch len(parts) {
+		case 2:
+		case 3:
+			if net.ParseIP(parts[0]) == nil {
+				return nil, errors.Errorf("invalid host ip in port %q", s)
+			}
+			m.HostIP = parts[0]
+			parts = parts[1:]
+		default:
+			return nil, errors.Errorf("invalid port %q", s)
+		}
+	}
+	host, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || host == 0 {
+		return nil, errors.Errorf("invalid host port in port %q", s)
+	}
+	container, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil || container == 0 {
+		return nil, errors.Errorf("invalid container port in port %q", s)
+	}
+	m.HostPort = uint32(host)
+	m.ContainerPort = uint32(container)
+	return m, nil
+}
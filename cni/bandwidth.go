@@ -0,0 +1,174 @@
+package cni
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// latency is the longest in milliseconds that a packet waits in the queue of a limited interface
+	latency = 25
+	// minBurst is the smallest burst in bytes so that full sized packets always fit
+	minBurst = 64 * 1024
+)
+
+// SetBandwidth limits the rates in bits per second of the container's interfaces
+// in the network namespace. A rate of 0 removes the limit.
+//
+// The queues are set up in the container's namespace so that macvlan interfaces, which
+// have no host side interface for the cni bandwidth plugin, are limited and the rates
+// can be changed without recreating the network.
+func SetBandwidth(path string, ingress, egress uint64) error {
+	ns, err := netns.GetFromPath(path)
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+	h, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return err
+	}
+	defer h.Delete()
+	links, err := h.LinkList()
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		name := l.Attrs().Name
+		if !strings.HasPrefix(name, "eth") {
+			continue
+		}
+		if err := setEgress(h, l, egress); err != nil {
+			return errors.Wrapf(err, "set egress rate of %s", name)
+		}
+		if err := setIngress(h, l, ingress); err != nil {
+			return errors.Wrapf(err, "set ingress rate of %s", name)
+		}
+	}
+	return nil
+}
+
+func setEgress(h *netlink.Handle, link netlink.Link, rate uint64) error {
+	if rate == 0 {
+		return deleteQdisc(h, link, netlink.HANDLE_ROOT)
+	}
+	return h.QdiscReplace(tbf(link.Attrs().Index, rate))
+}
+
+// setIngress redirects the traffic received on the interface to an ifb
+// where it is shaped as it leaves
+func setIngress(h *netlink.Handle, link netlink.Link, rate uint64) error {
+	name := "ifb-" + link.Attrs().Name
+	if rate == 0 {
+		if err := deleteQdisc(h, link, netlink.HANDLE_INGRESS); err != nil {
+			return err
+		}
+		ifb, err := h.LinkByName(name)
+		if err != nil {
+			if _, ok := err.(netlink.LinkNotFoundError); ok {
+				return nil
+			}
+			return err
+		}
+		return h.LinkDel(ifb)
+	}
+	ifb, err := h.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return err
+		}
+		if err := h.LinkAdd(&netlink.Ifb{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:   name,
+				TxQLen: 1000,
+			},
+		}); err != nil {
+			return err
+		}
+		if ifb, err = h.LinkByName(name); err != nil {
+			return err
+		}
+	}
+	if err := h.LinkSetUp(ifb); err != nil {
+		return err
+	}
+	if err := h.QdiscReplace(tbf(ifb.Attrs().Index, rate)); err != nil {
+		return err
+	}
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := h.QdiscReplace(ingress); err != nil {
+		return err
+	}
+	filters, err := h.FilterList(link, ingress.Handle)
+	if err != nil {
+		return err
+	}
+	// the redirect is kept when only the rate changes
+	if len(filters) > 0 {
+		return nil
+	}
+	return h.FilterAdd(&netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		ClassId: netlink.MakeHandle(1, 1),
+		// netlink adds the mirred action that redirects to the ifb device
+		RedirIndex: ifb.Attrs().Index,
+	})
+}
+
+// tbf returns a token bucket filter limiting the interface to the rate
+func tbf(index int, rate uint64) *netlink.Tbf {
+	var (
+		bytes = rate / 8
+		// 10ms worth of traffic
+		burst = bytes / 100
+	)
+	if burst < minBurst {
+		burst = minBurst
+	}
+	buffer := uint32(float64(burst) * netlink.TIME_UNITS_PER_SEC / float64(bytes) * netlink.TickInUsec())
+	return &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   bytes,
+		Limit:  uint32(float64(bytes)*latency/1000) + uint32(burst),
+		Buffer: buffer,
+	}
+}
+
+func deleteQdisc(h *netlink.Handle, link netlink.Link, parent uint32) error {
+	qdiscs, err := h.QdiscList(link)
+	if err != nil {
+		return err
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != parent {
+			continue
+		}
+		// the default root qdisc of the interface cannot be removed
+		if _, ok := q.(*netlink.Tbf); !ok && parent == netlink.HANDLE_ROOT {
+			continue
+		}
+		if err := h.QdiscDel(q); err != nil && err != unix.ENOENT {
+			return err
+		}
+	}
+	return nil
+}
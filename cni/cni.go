@@ -2,96 +2,203 @@ package cni
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"syscall"
+	"runtime"
 
 	"github.com/containerd/containerd"
 	networking "github.com/containerd/go-cni"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/policy"
 	"github.com/crosbymichael/boss/route"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
 	"golang.org/x/sys/unix"
 )
 
-func New(t, iface, mvlanAddress string, n networking.CNI) (v1.Network, error) {
-	if t == "macvlan" {
-		if err := route.Create(iface, mvlanAddress); err != nil {
-			return nil, err
+// Network is a cni network that containers are attached to
+type Network struct {
+	Name string
+	Type string
+	// Device is the macvlan device on the host used to route to containers
+	Device        string
+	BridgeAddress string
+	// ClusterIPAM networks get the address reserved for the container in the cluster's store
+	ClusterIPAM bool
+	Subnet      string
+}
+
+// New returns a network for containers attached to all of the networks.
+// Each network is a separate interface in the container in the order of the networks
+// with the first being the container's primary network.
+// Addresses reserved with the cluster ipam are released with the ipam when it is set
+// and the services allowed by network policies are resolved with the register.
+func New(iface string, networks []Network, n networking.CNI, ipam v1.IPAM, register v1.Register) (v1.Network, error) {
+	for _, nw := range networks {
+		if nw.Type == "macvlan" {
+			if err := route.Create(nw.Device, iface, nw.BridgeAddress); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return &cni{
-		network: n,
-		nt:      t,
+		network:  n,
+		networks: networks,
+		ipam:     ipam,
+		register: register,
 	}, nil
 }
 
 type cni struct {
-	network networking.CNI
-	nt      string
+	network  networking.CNI
+	networks []Network
+	ipam     v1.IPAM
+	register v1.Register
 }
 
-func (n *cni) Create(ctx context.Context, task containerd.Container) (string, error) {
+func (n *cni) Create(ctx context.Context, task containerd.Container) ([]string, error) {
 	path := v1.NetworkPath(task.ID())
 	if _, err := os.Lstat(path); err != nil {
 		if !os.IsNotExist(err) {
-			return "", err
+			return nil, err
 		}
 		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
-			return "", err
+			return nil, err
 		}
 		if err := createNetns(path); err != nil {
-			return "", err
+			return nil, err
 		}
-		result, err := n.network.Setup(task.ID(), path)
+		o, err := portMappings(ctx, task)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		var ip net.IP
-		for _, ipc := range result.Interfaces["eth0"].IPConfigs {
-			if f := ipc.IP.To4(); f != nil {
-				ip = f
-				break
+		reserved, err := n.reservedIPs(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, reserved...)
+		result, err := n.network.Setup(task.ID(), path, o...)
+		if err != nil {
+			return nil, err
+		}
+		ips := make(map[string][]string, len(n.networks))
+		for i, nw := range n.networks {
+			iface := fmt.Sprintf("eth%d", i)
+			config, ok := result.Interfaces[iface]
+			if !ok {
+				return nil, errors.Errorf("no interface %s for network %s", iface, nw.Name)
+			}
+			addrs := addresses(config.IPConfigs)
+			if len(addrs) == 0 {
+				return nil, errors.Errorf("no ip for network %s", nw.Name)
+			}
+			ips[nw.Name] = addrs
+			if nw.Type == "macvlan" {
+				for _, ip := range addrs {
+					if err := route.Add(nw.Device, ip); err != nil {
+						return nil, err
+					}
+				}
 			}
 		}
-		if err := task.Update(ctx, opts.WithIP(ip.String())); err != nil {
-			return "", err
+		config, err := opts.GetConfig(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if config.Allow != nil {
+			rules, err := policy.Rules(config.Allow, n.register.Resolve)
+			if err != nil {
+				return nil, err
+			}
+			if err := policy.Apply(path, rules); err != nil {
+				return nil, errors.Wrap(err, "apply network policy")
+			}
 		}
-		if n.nt == "macvlan" {
-			route.Remove(ip.String())
-			if err := route.Add(ip.String()); err != nil {
-				return "", err
+		if config.IngressRate > 0 || config.EgressRate > 0 {
+			if err := SetBandwidth(path, config.IngressRate, config.EgressRate); err != nil {
+				return nil, err
 			}
 		}
-		return ip.String(), nil
+		primary := ips[n.networks[0].Name]
+		if err := task.Update(ctx, opts.WithIPs(primary), opts.WithNetworkIPs(ips)); err != nil {
+			return nil, err
+		}
+		return primary, nil
 	}
 	l, err := task.Labels(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	return opts.IPs(l), nil
+}
+
+// addresses returns the first ipv4 and ipv6 address of the interface with ipv4 first
+func addresses(configs []*networking.IPConfig) []string {
+	var v4, v6 string
+	for _, ipc := range configs {
+		switch {
+		case ipc.IP.To4() != nil:
+			if v4 == "" {
+				v4 = ipc.IP.To4().String()
+			}
+		case ipc.IP.IsGlobalUnicast():
+			if v6 == "" {
+				v6 = ipc.IP.String()
+			}
+		}
 	}
-	return l[opts.IPLabel], nil
+	var o []string
+	for _, ip := range []string{v4, v6} {
+		if ip != "" {
+			o = append(o, ip)
+		}
+	}
+	return o
 }
 
 func (n *cni) Remove(ctx context.Context, c containerd.Container) error {
 	path := v1.NetworkPath(c.ID())
-	if err := n.network.Remove(c.ID(), path); err != nil {
+	// the same port mappings are required to remove the rules for published ports
+	ports, err := portMappings(ctx, c)
+	if err != nil {
+		return err
+	}
+	config, err := opts.GetConfig(ctx, c)
+	if err != nil {
+		return err
+	}
+	if config.Allow != nil {
+		if err := policy.Remove(path); err != nil {
+			logrus.WithError(err).Error("remove network policy")
+		}
+	}
+	if err := n.network.Remove(c.ID(), path, ports...); err != nil {
 		logrus.WithError(err).Error("remove cni networking")
 	}
 	if err := unix.Unmount(path, 0); err != nil {
 		logrus.WithError(err).Error("unmount netns")
 	}
-	if n.nt == "macvlan" {
-		info, err := c.Info(ctx)
-		if err != nil {
-			return err
+	info, err := c.Info(ctx)
+	if err != nil {
+		return err
+	}
+	for _, nw := range n.networks {
+		if nw.ClusterIPAM && n.ipam != nil {
+			if err := n.ipam.Release(nw.Name, c.ID()); err != nil {
+				logrus.WithError(err).Errorf("release ip on network %s", nw.Name)
+			}
 		}
-		ip := info.Labels[opts.IPLabel]
-		if ip != "" {
-			if err := route.Remove(ip); err != nil {
+	}
+	for i, nw := range n.networks {
+		if nw.Type != "macvlan" {
+			continue
+		}
+		for _, ip := range opts.NetworkAddresses(info.Labels, nw.Name, i == 0) {
+			if err := route.Remove(nw.Device, ip); err != nil {
 				logrus.WithError(err).Error("remove routes")
 			}
 		}
@@ -100,14 +207,80 @@ func (n *cni) Remove(ctx context.Context, c containerd.Container) error {
 	return os.RemoveAll(filepath.Dir(path))
 }
 
-func createNetns(path string) error {
-	cmd := exec.Command("boss", "network", "create", path)
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Cloneflags: unix.CLONE_NEWNET,
+func portMappings(ctx context.Context, c containerd.Container) ([]networking.NamespaceOpts, error) {
+	config, err := opts.GetConfig(ctx, c)
+	if err != nil {
+		return nil, err
 	}
-	out, err := cmd.CombinedOutput()
+	if len(config.Ports) == 0 {
+		return nil, nil
+	}
+	var ports []networking.PortMapping
+	for _, p := range config.Ports {
+		ports = append(ports, networking.PortMapping{
+			HostPort:      int32(p.HostPort),
+			ContainerPort: int32(p.ContainerPort),
+			Protocol:      p.Protocol,
+			HostIP:        p.HostIP,
+		})
+	}
+	return []networking.NamespaceOpts{
+		networking.WithCapabilityPortMap(ports),
+	}, nil
+}
+
+// reservedIPs passes the address reserved for the container to the network using the cluster ipam
+func (n *cni) reservedIPs(ctx context.Context, c containerd.Container) ([]networking.NamespaceOpts, error) {
+	labels, err := c.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, nw := range n.networks {
+		if !nw.ClusterIPAM {
+			continue
+		}
+		ip := labels[opts.ReservedIPLabel(nw.Name)]
+		if ip == "" {
+			return nil, errors.Errorf("no ip reserved for network %s", nw.Name)
+		}
+		_, subnet, err := net.ParseCIDR(nw.Subnet)
+		if err != nil {
+			return nil, err
+		}
+		ones, _ := subnet.Mask.Size()
+		return []networking.NamespaceOpts{
+			networking.WithCapability("ips", []string{fmt.Sprintf("%s/%d", ip, ones)}),
+		}, nil
+	}
+	return nil, nil
+}
+
+// createNetns creates a new network namespace bind mounted at path
+func createNetns(path string) (err error) {
+	f, err := os.Create(path)
 	if err != nil {
-		return errors.Wrap(err, string(out))
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
 	}
-	return nil
+	defer func() {
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+	errCh := make(chan error, 1)
+	go func() {
+		// the thread is never unlocked so that it exits with the goroutine
+		// instead of being reused while in the new namespace
+		runtime.LockOSThread()
+		ns, err := netns.New()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer ns.Close()
+		errCh <- unix.Mount(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()), path, "none", unix.MS_BIND, "")
+	}()
+	return <-errCh
 }
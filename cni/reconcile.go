@@ -0,0 +1,120 @@
+package cni
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/route"
+	"github.com/sirupsen/logrus"
+)
+
+// Reconcile adds missing host routes for the active containers on the macvlan networks
+// and removes the routes to addresses that no active container has
+func Reconcile(ctx context.Context, client *containerd.Client, networks []Network) error {
+	expected, err := containerRoutes(ctx, client)
+	if err != nil {
+		return err
+	}
+	for _, nw := range networks {
+		if nw.Type != "macvlan" {
+			continue
+		}
+		existing, err := route.List(nw.Device)
+		if err != nil {
+			// the device is created with the first container on the network
+			if route.IsDeviceNotFound(err) {
+				continue
+			}
+			return err
+		}
+		want := expected[nw.Name]
+		for _, ip := range existing {
+			if _, ok := want[ip]; ok {
+				delete(want, ip)
+				continue
+			}
+			logrus.WithFields(logrus.Fields{
+				"network": nw.Name,
+				"ip":      ip,
+			}).Info("remove orphaned route")
+			if err := route.Remove(nw.Device, ip); err != nil {
+				return err
+			}
+		}
+		for ip, id := range want {
+			logrus.WithFields(logrus.Fields{
+				"network": nw.Name,
+				"ip":      ip,
+				"id":      id,
+			}).Info("add missing route")
+			if err := route.Add(nw.Device, ip); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// containerRoutes returns the ips of active containers by network
+func containerRoutes(ctx context.Context, client *containerd.Client) (map[string]map[string]string, error) {
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes := make(map[string]map[string]string)
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		active, err := isActive(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			continue
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			continue
+		}
+		names := config.Networks
+		if len(names) == 0 {
+			names = []string{config.Network}
+		}
+		for i, name := range names {
+			for _, ip := range opts.NetworkAddresses(info.Labels, name, i == 0) {
+				if routes[name] == nil {
+					routes[name] = make(map[string]string)
+				}
+				routes[name][ip] = c.ID()
+			}
+		}
+	}
+	return routes, nil
+}
+
+// isActive returns true if the container has a task that has not stopped,
+// a created task already has its network setup
+func isActive(ctx context.Context, c containerd.Container) (bool, error) {
+	task, err := c.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return status.Status != containerd.Stopped, nil
+}
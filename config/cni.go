@@ -3,8 +3,12 @@ package config
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/route"
 	"github.com/urfave/cli"
 )
 
@@ -17,6 +21,32 @@ type CNI struct {
 	IPAM          IPAM   `toml:"ipam" json:"ipam"`
 	Bridge        string `toml:"bridge" json:"bridge,omitempty"`
 	BridgeAddress string `toml:"bridge_address" json:"-"`
+	// Device is the macvlan device created on the host for routing to containers
+	Device string `toml:"device" json:"-"`
+	// Capabilities are the runtime config the plugin accepts
+	Capabilities map[string]bool `toml:"-" json:"capabilities,omitempty"`
+
+	// name of the network in the system config
+	name string
+}
+
+// ClusterIPAM is the ipam type that reserves container addresses in the cluster's store
+const ClusterIPAM = "boss"
+
+// device returns the macvlan device on the host for the network
+func (c *CNI) device(name string) string {
+	if c.Device != "" {
+		return c.Device
+	}
+	if name == "cni" {
+		return route.Interface
+	}
+	// interface names are limited to 15 characters
+	d := "mv-" + name
+	if len(d) > 15 {
+		d = d[:15]
+	}
+	return d
 }
 
 func (c *CNI) SubSteps() (o []Step) {
@@ -26,6 +56,77 @@ func (c *CNI) SubSteps() (o []Step) {
 	return o
 }
 
+// ConfListDir is where the network config lists with the cni plugin chains are written
+const ConfListDir = "/etc/boss/cni"
+
+type confList struct {
+	Version string        `json:"cniVersion"`
+	Name    string        `json:"name"`
+	Plugins []interface{} `json:"plugins"`
+}
+
+type portMap struct {
+	Type         string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+	SNAT         bool            `json:"snat"`
+}
+
+// ConfList returns the network config as a list.
+// The network is chained with the portmap plugin when ports are published on it.
+func (c *CNI) ConfList(ports bool) []byte {
+	conf := *c
+	// reserved addresses are passed to host-local with the ips capability
+	if c.IPAM.Type == ClusterIPAM {
+		conf.Capabilities = map[string]bool{
+			"ips": true,
+		}
+	}
+	plugins := []interface{}{
+		&conf,
+	}
+	if ports {
+		plugins = append(plugins, portMap{
+			Type: "portmap",
+			Capabilities: map[string]bool{
+				"portMappings": true,
+			},
+			SNAT: true,
+		})
+	}
+	data, err := json.Marshal(confList{
+		Version: c.Version,
+		Name:    c.NetworkName,
+		Plugins: plugins,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// WriteConfList atomically writes the network config list to the ConfListDir
+// and returns its path
+func (c *CNI) WriteConfList(name string, ports bool) (string, error) {
+	if err := os.MkdirAll(ConfListDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(ConfListDir, name+".conflist")
+	if !ports {
+		path = filepath.Join(ConfListDir, name+".noports.conflist")
+	}
+	tmp, err := ioutil.TempFile(ConfListDir, ".cni")
+	if err != nil {
+		return "", err
+	}
+	_, err = tmp.Write(c.ConfList(ports))
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return path, os.Rename(tmp.Name(), path)
+}
+
 func (c *CNI) Bytes() []byte {
 	data, err := json.Marshal(c)
 	if err != nil {
@@ -37,10 +138,45 @@ func (c *CNI) Bytes() []byte {
 type IPAM struct {
 	Type   string `toml:"type" json:"type"`
 	Subnet string `toml:"subnet" json:"subnet"`
+	// Subnet6 is the ipv6 subnet of dual-stack and ipv6 only networks
+	Subnet6 string `toml:"subnet6" json:"-"`
+}
+
+type ipamRange struct {
+	Subnet string `json:"subnet"`
+}
+
+// MarshalJSON uses host-local ranges to allocate an address from each subnet
+// when the network has an ipv6 subnet.
+// The cluster ipam hands its reserved addresses to host-local.
+func (i IPAM) MarshalJSON() ([]byte, error) {
+	type ipam IPAM
+	if i.Type == ClusterIPAM {
+		i.Type = "host-local"
+	}
+	if i.Subnet6 == "" {
+		return json.Marshal(ipam(i))
+	}
+	var ranges [][]ipamRange
+	for _, subnet := range []string{i.Subnet, i.Subnet6} {
+		if subnet != "" {
+			ranges = append(ranges, []ipamRange{{Subnet: subnet}})
+		}
+	}
+	return json.Marshal(struct {
+		Type   string        `json:"type"`
+		Ranges [][]ipamRange `json:"ranges"`
+	}{
+		Type:   i.Type,
+		Ranges: ranges,
+	})
 }
 
 func (s *CNI) Name() string {
-	return "cni"
+	if s.name == "" {
+		return "cni"
+	}
+	return s.name
 }
 
 func (s *CNI) Run(ctx context.Context, client *containerd.Client, clix *cli.Context) error {
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"os"
+	"sort"
 	"sync"
 
 	"github.com/BurntSushi/toml"
@@ -21,7 +22,10 @@ const (
 )
 
 type ConfigStore interface {
+	// Write stores the content of the container's configs that do not exist in the store
 	Write(context.Context, *v1.Container) error
+	// Replace overwrites the content of an existing config
+	Replace(context.Context, *v1.Config) error
 	Watch(context.Context, containerd.Container, *v1.Container) (<-chan error, error)
 }
 
@@ -42,9 +46,29 @@ func Load() (*Config, error) {
 	if c.Iface == "" {
 		c.Iface = "eth0"
 	}
+	if err := c.checkDevices(); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
+// checkDevices ensures that each macvlan network has its own device on the host
+// as generated device names are truncated to the interface name limit
+func (c *Config) checkDevices() error {
+	devices := make(map[string]string)
+	for _, n := range c.cniNetworks() {
+		if n.Type != "macvlan" {
+			continue
+		}
+		d := n.device(n.Name())
+		if other, ok := devices[d]; ok {
+			return errors.Errorf("networks %s and %s use the same host device %s, set a device for one of them", other, n.Name(), d)
+		}
+		devices[d] = n.Name()
+	}
+	return nil
+}
+
 var (
 	consul     *api.Client
 	consulErr  error
@@ -69,6 +93,18 @@ type Config struct {
 	Agent        Agent         `toml:"agent"`
 	Containerd   Containerd    `toml:"containerd"`
 	Criu         *Criu         `toml:"criu"`
+	Ingress      *Ingress      `toml:"ingress"`
+	Resolver     *Resolver     `toml:"resolver"`
+	Registry     *Registry     `toml:"registry"`
+	// Networks are named cni networks that containers can attach to
+	Networks map[string]*CNI `toml:"networks"`
+
+	ipam v1.IPAM
+}
+
+// SetIPAM sets the ipam that releases the addresses of networks using the cluster ipam
+func (c *Config) SetIPAM(ipam v1.IPAM) {
+	c.ipam = ipam
 }
 
 func (c *Config) Store() (ConfigStore, error) {
@@ -86,7 +122,7 @@ func (c *Config) Store() (ConfigStore, error) {
 
 // GetNetwork returns a network for the givin name
 func (c *Config) GetNetwork(name string) (v1.Network, error) {
-	ip, err := util.GetIP(c.Iface)
+	ips, err := util.GetIPs(c.Iface)
 	if err != nil {
 		return nil, err
 	}
@@ -94,36 +130,154 @@ func (c *Config) GetNetwork(name string) (v1.Network, error) {
 	case "", "none":
 		return &none{}, nil
 	case "host":
-		return &host{ip: ip}, nil
-	case "cni":
-		if c.CNI == nil {
-			return nil, errors.New("[cni] is not enabled in the system config")
+		return &host{ips: ips}, nil
+	}
+	return c.cniNetwork(name)
+}
+
+// ContainerNetwork returns the network for all networks the container is attached to
+func (c *Config) ContainerNetwork(container *v1.Container) (v1.Network, error) {
+	if len(container.Networks) == 0 {
+		return c.GetNetwork(container.Network)
+	}
+	return c.cniNetwork(container.Networks...)
+}
+
+// ContainerNetworks returns the cni networks the container is attached to with the first as the primary
+func (c *Config) ContainerNetworks(container *v1.Container) ([]cni.Network, error) {
+	var networks []cni.Network
+	for _, name := range v1.NetworkNames(container) {
+		nw, err := c.cniNetworkInfo(name)
+		if err != nil {
+			return nil, err
 		}
-		if c.CNI.Type == "macvlan" && c.CNI.BridgeAddress == "" {
-			return nil, errors.New("bridge_address must be specified with macvlan")
+		networks = append(networks, nw)
+	}
+	return networks, nil
+}
+
+// cniNetwork returns a network attached to the named cni networks with the first as the primary
+func (c *Config) cniNetwork(names ...string) (v1.Network, error) {
+	var (
+		networks []cni.Network
+		cluster  int
+		o        = []gocni.CNIOpt{
+			gocni.WithPluginDir([]string{"/opt/containerd/bin"}),
+		}
+	)
+	for i, name := range names {
+		conf, err := c.cniConfig(name)
+		if err != nil {
+			return nil, err
 		}
-		// populate cni data from main config if fields are missing
-		c.CNI.Version = "0.3.1"
-		if c.CNI.NetworkName == "" {
-			c.CNI.NetworkName = c.Domain
+		// ports are only published on the primary network
+		path, err := conf.WriteConfList(name, i == 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "write cni config list")
 		}
-		if c.CNI.Master == "" {
-			c.CNI.Master = c.Iface
+		o = append(o, gocni.WithConfListFile(path))
+		nw := networkInfo(name, conf)
+		if nw.ClusterIPAM {
+			cluster++
 		}
-		n, err := gocni.New(
-			gocni.WithPluginDir([]string{"/opt/containerd/bin"}),
-			gocni.WithConf(c.CNI.Bytes()),
-			gocni.WithLoNetwork,
-		)
+		networks = append(networks, nw)
+	}
+	// the reserved address is passed to all networks with the ips capability
+	if cluster > 1 {
+		return nil, errors.Errorf("only one network of a container can use the %s ipam", ClusterIPAM)
+	}
+	// lo must be added last so the interfaces of the networks are numbered from eth0
+	o = append(o, gocni.WithLoNetwork)
+	n, err := gocni.New(o...)
+	if err != nil {
+		return nil, err
+	}
+	register, err := c.GetRegister()
+	if err != nil {
+		return nil, err
+	}
+	return cni.New(c.Iface, networks, n, c.ipam, register)
+}
+
+// MacvlanNetworks returns the macvlan networks that routes are managed for on the host
+func (c *Config) MacvlanNetworks() ([]cni.Network, error) {
+	var names []string
+	if c.CNI != nil {
+		names = append(names, "cni")
+	}
+	for name := range c.Networks {
+		names = append(names, name)
+	}
+	var networks []cni.Network
+	for _, name := range names {
+		nw, err := c.cniNetworkInfo(name)
 		if err != nil {
 			return nil, err
 		}
-		return cni.New(c.CNI.Type, c.Iface, c.CNI.BridgeAddress, n)
+		if nw.Type != "macvlan" {
+			continue
+		}
+		networks = append(networks, nw)
+	}
+	return networks, nil
+}
+
+func (c *Config) cniNetworkInfo(name string) (cni.Network, error) {
+	conf, err := c.cniConfig(name)
+	if err != nil {
+		return cni.Network{}, err
+	}
+	return networkInfo(name, conf), nil
+}
+
+func networkInfo(name string, conf *CNI) cni.Network {
+	return cni.Network{
+		Name:          name,
+		Type:          conf.Type,
+		Device:        conf.device(name),
+		BridgeAddress: conf.BridgeAddress,
+		ClusterIPAM:   conf.IPAM.Type == ClusterIPAM,
+		Subnet:        conf.IPAM.Subnet,
 	}
-	return nil, errors.Errorf("network %s does not exist", name)
+}
+
+// cniConfig returns the config for the named cni network.
+// The network from the [cni] block is named cni.
+func (c *Config) cniConfig(name string) (*CNI, error) {
+	conf := c.Networks[name]
+	if name == "cni" {
+		if c.CNI == nil {
+			return nil, errors.New("[cni] is not enabled in the system config")
+		}
+		conf = c.CNI
+	}
+	if conf == nil {
+		return nil, errors.Errorf("network %s does not exist", name)
+	}
+	if conf.Type == "macvlan" && conf.BridgeAddress == "" {
+		return nil, errors.Errorf("bridge_address must be specified with macvlan for network %s", name)
+	}
+	if conf.IPAM.Type == ClusterIPAM && conf.IPAM.Subnet == "" {
+		return nil, errors.Errorf("ipam subnet must be specified with the %s ipam for network %s", ClusterIPAM, name)
+	}
+	// populate cni data from main config if fields are missing
+	conf.Version = "0.3.1"
+	if conf.NetworkName == "" {
+		conf.NetworkName = name
+		if name == "cni" {
+			conf.NetworkName = c.Domain
+		}
+	}
+	if conf.Master == "" {
+		conf.Master = c.Iface
+	}
+	return conf, nil
 }
 
 func (c *Config) GetRegister() (v1.Register, error) {
+	if c.Registry != nil {
+		return c.Registry.register(c.ID)
+	}
 	if c.Consul != nil {
 		consulOnce.Do(getConsul)
 		if consulErr != nil {
@@ -182,9 +336,14 @@ func (c *Config) Steps() []Step {
 			})
 		}
 	}
-	if c.CNI != nil {
-		steps = append(steps, c.CNI)
-		steps = append(steps, c.CNI.SubSteps()...)
+	var dhcp bool
+	for _, n := range c.cniNetworks() {
+		steps = append(steps, n)
+		// a single dhcp daemon serves all networks
+		if sub := n.SubSteps(); len(sub) > 0 && !dhcp {
+			dhcp = true
+			steps = append(steps, sub...)
+		}
 	}
 	if c.MOTD != nil {
 		steps = append(steps, c.MOTD)
@@ -201,6 +360,26 @@ func (c *Config) Steps() []Step {
 	return steps
 }
 
+// cniNetworks returns copies of the [cni] network and all named networks with their names
+func (c *Config) cniNetworks() (o []*CNI) {
+	if c.CNI != nil {
+		n := *c.CNI
+		n.name = "cni"
+		o = append(o, &n)
+	}
+	var names []string
+	for name := range c.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		n := *c.Networks[name]
+		n.name = name
+		o = append(o, &n)
+	}
+	return o
+}
+
 func (c *Config) consul() bool {
 	return c.Consul != nil
 }
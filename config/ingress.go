@@ -0,0 +1,27 @@
+package config
+
+// Ingress serves http on the node and routes requests to the ingress of container services
+type Ingress struct {
+	// Address for http, defaults to :80
+	Address string `toml:"address"`
+	// TLSAddress for https, only served when a cert and key are provided
+	TLSAddress string `toml:"tls_address"`
+	Cert       string `toml:"cert"`
+	Key        string `toml:"key"`
+}
+
+// HTTPAddress returns the address for http
+func (i *Ingress) HTTPAddress() string {
+	if i.Address == "" {
+		return ":80"
+	}
+	return i.Address
+}
+
+// HTTPSAddress returns the address for https
+func (i *Ingress) HTTPSAddress() string {
+	if i.TLSAddress == "" {
+		return ":443"
+	}
+	return i.TLSAddress
+}
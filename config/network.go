@@ -7,11 +7,11 @@ import (
 )
 
 type host struct {
-	ip string
+	ips []string
 }
 
-func (n *host) Create(_ context.Context, _ containerd.Container) (string, error) {
-	return n.ip, nil
+func (n *host) Create(_ context.Context, _ containerd.Container) ([]string, error) {
+	return n.ips, nil
 }
 
 func (n *host) Remove(_ context.Context, _ containerd.Container) error {
@@ -21,8 +21,8 @@ func (n *host) Remove(_ context.Context, _ containerd.Container) error {
 type none struct {
 }
 
-func (n *none) Create(_ context.Context, _ containerd.Container) (string, error) {
-	return "", nil
+func (n *none) Create(_ context.Context, _ containerd.Container) ([]string, error) {
+	return nil, nil
 }
 
 func (n *none) Remove(_ context.Context, _ containerd.Container) error {
@@ -6,10 +6,20 @@ type nullRegister struct {
 }
 
 // Register sends the provided service registration to the local agent
-func (c *nullRegister) Register(id, name, ip string, s *v1.Service) error {
+func (c *nullRegister) Register(id, name string, ips []string, s *v1.Service) error {
 	return nil
 }
 
+// Resolve returns no addresses as services are not registered
+func (c *nullRegister) Resolve(_ string) ([]string, error) {
+	return nil, nil
+}
+
+// Registrations returns no services as services are not registered
+func (c *nullRegister) Registrations() ([]*v1.Registration, error) {
+	return nil, nil
+}
+
 // Deregister sends the provided service registration to the local agent
 func (c *nullRegister) Deregister(_, _ string) error {
 	return nil
@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/containerd/containerd"
 	"github.com/crosbymichael/boss/api/v1"
@@ -49,7 +51,7 @@ func (s *RegisterService) Run(ctx context.Context, client *containerd.Client, cl
 		if s.Check.Timeout != 0 {
 			check.Timeout = fmt.Sprintf("%ds", s.Check.Timeout)
 		}
-		addr := fmt.Sprintf("%s:%d", ip, s.Port)
+		addr := net.JoinHostPort(ip, strconv.Itoa(s.Port))
 		switch s.Check.Type {
 		case "http":
 			url := ""
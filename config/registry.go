@@ -0,0 +1,64 @@
+package config
+
+import (
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/consulregister"
+	"github.com/crosbymichael/boss/kvregister"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+const (
+	ConsulRegistry = "consul"
+	StoreRegistry  = "store"
+	EtcdRegistry   = "etcd"
+)
+
+var (
+	storePool     *redis.Pool
+	storePoolOnce sync.Once
+)
+
+// Registry selects the backend where container services are registered
+type Registry struct {
+	// Type is consul, store for the agent's replicated store, or etcd
+	Type string `toml:"type"`
+	// Endpoints are the urls of the etcd cluster
+	Endpoints []string `toml:"endpoints"`
+}
+
+func (r *Registry) register(node string) (v1.Register, error) {
+	switch r.Type {
+	case ConsulRegistry:
+		consulOnce.Do(getConsul)
+		if consulErr != nil {
+			return nil, consulErr
+		}
+		return consulregister.New(consul), nil
+	case StoreRegistry:
+		storePoolOnce.Do(newStorePool)
+		return kvregister.New(kvregister.NewLedis(storePool), node), nil
+	case EtcdRegistry:
+		if len(r.Endpoints) == 0 {
+			return nil, errors.New("etcd registry requires endpoints")
+		}
+		return kvregister.New(kvregister.NewEtcd(r.Endpoints), node), nil
+	}
+	return nil, errors.Errorf("unknown registry type %q", r.Type)
+}
+
+// newStorePool connects to the master store published by the agent so that
+// registrations from the container processes are written to the whole cluster
+func newStorePool() {
+	storePool = redis.NewPool(func() (redis.Conn, error) {
+		address, err := ioutil.ReadFile(v1.StoreAddressPath())
+		if err != nil {
+			return nil, errors.Wrap(err, "read store address of the agent")
+		}
+		return redis.Dial("tcp", strings.TrimSpace(string(address)))
+	}, 5)
+}
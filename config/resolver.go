@@ -0,0 +1,55 @@
+package config
+
+import (
+	"net"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+// Resolver is the dns server of the agent that answers for the services and
+// containers of the cluster without consul
+type Resolver struct {
+	// Address defaults to :53
+	Address string `toml:"address"`
+	// Upstreams answer the queries outside of the domain,
+	// defaults to the nameservers of /etc/resolv.conf
+	Upstreams []string `toml:"upstreams"`
+}
+
+// ListenAddress returns the address the dns server listens on
+func (r *Resolver) ListenAddress() string {
+	if r.Address == "" {
+		return ":53"
+	}
+	return r.Address
+}
+
+// UpstreamAddresses returns the addresses of the upstream nameservers
+func (r *Resolver) UpstreamAddresses() ([]string, error) {
+	var upstreams []string
+	for _, u := range r.Upstreams {
+		if _, _, err := net.SplitHostPort(u); err != nil {
+			u = net.JoinHostPort(u, "53")
+		}
+		upstreams = append(upstreams, u)
+	}
+	if len(upstreams) > 0 {
+		return upstreams, nil
+	}
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range conf.Servers {
+		// the node's resolv.conf points at the local server
+		if ip := net.ParseIP(s); ip != nil && ip.IsLoopback() {
+			continue
+		}
+		upstreams = append(upstreams, net.JoinHostPort(s, conf.Port))
+	}
+	if len(upstreams) == 0 {
+		return nil, errors.New("no upstream nameservers, set upstreams in [resolver]")
+	}
+	return upstreams, nil
+}
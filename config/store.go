@@ -10,6 +10,7 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/oci"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
 	"github.com/hashicorp/consul/api"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -28,6 +29,10 @@ func (l *nullStore) Write(_ context.Context, c *v1.Container) error {
 	return nil
 }
 
+func (l *nullStore) Replace(_ context.Context, _ *v1.Config) error {
+	return ErrConfigStoreNotSupported
+}
+
 func (l *nullStore) Watch(_ context.Context, _ containerd.Container, _ *v1.Container) (<-chan error, error) {
 	return make(chan error), nil
 }
@@ -56,6 +61,14 @@ func (l *configStore) Write(ctx context.Context, c *v1.Container) error {
 	return nil
 }
 
+func (l *configStore) Replace(ctx context.Context, f *v1.Config) error {
+	_, err := l.consul.KV().Put(&api.KVPair{
+		Key:   f.Source,
+		Value: []byte(f.Content),
+	}, nil)
+	return err
+}
+
 func (l *configStore) Watch(ctx context.Context, c containerd.Container, cfg *v1.Container) (<-chan error, error) {
 	var (
 		ch = make(chan error, len(cfg.Configs))
@@ -110,7 +123,8 @@ func (t *Template) Render(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if err := f.Chown(int(t.Spec.Process.User.UID), int(t.Spec.Process.User.GID)); err != nil {
+	uid, gid := opts.HostIDs(t.Spec)
+	if err := f.Chown(uid, gid); err != nil {
 		f.Close()
 		return err
 	}
@@ -2,12 +2,22 @@ package consulregister
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/hashicorp/consul/api"
 )
 
+const (
+	// containerMeta marks the services registered for containers
+	containerMeta = "boss.io/container"
+	// maintenancePrefix is the prefix of the check id consul adds for a service in maintenance
+	maintenancePrefix = "_service_maintenance:"
+)
+
 func New(client *api.Client) *Consul {
 	return &Consul{
 		client: client,
@@ -19,37 +29,159 @@ type Consul struct {
 	client *api.Client
 }
 
-// Register sends the provided service registration to the local agent
-func (c *Consul) Register(id, name, ip string, s *v1.Service) error {
-	reg := c.registration(id, name, ip, s)
-	if err := c.client.Agent().ServiceRegister(reg); err != nil {
+// Register sends the provided service registration to the local agent.
+// Consul services have a single address so a container's ipv6 address
+// is registered as a second instance of the service.
+func (c *Consul) Register(id, name string, ips []string, s *v1.Service) error {
+	registered, err := c.registered(id, name)
+	if err != nil {
 		return err
 	}
-	return c.client.Agent().EnableServiceMaintenance(serviceID(id, name), "created")
+	wanted := make(map[string]struct{})
+	for i, ip := range ips {
+		sid := addressID(id, name, i)
+		wanted[sid] = struct{}{}
+		if err := c.client.Agent().ServiceRegister(c.registration(id, sid, name, ip, s)); err != nil {
+			return err
+		}
+		if err := c.client.Agent().EnableServiceMaintenance(sid, v1.MaintenanceCreated); err != nil {
+			return err
+		}
+	}
+	// remove the instance of an address the container no longer has
+	for _, sid := range registered {
+		if _, ok := wanted[sid]; !ok {
+			if err := c.client.Agent().ServiceDeregister(sid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // Deregister sends the provided service registration to the local agent
 func (c *Consul) Deregister(id, name string) error {
-	return c.client.Agent().ServiceDeregister(serviceID(id, name))
+	return c.each(id, name, func(sid string) error {
+		return c.client.Agent().ServiceDeregister(sid)
+	})
 }
 
 // EnableMaintainance places the specific service in maintainace mode
 func (c *Consul) EnableMaintainance(id, name, reason string) error {
-	return c.client.Agent().EnableServiceMaintenance(serviceID(id, name), reason)
+	return c.each(id, name, func(sid string) error {
+		return c.client.Agent().EnableServiceMaintenance(sid, reason)
+	})
 }
 
 // DisableMaintainance removes the specific service out of maintainace mode
 func (c *Consul) DisableMaintainance(id, name string) error {
-	return c.client.Agent().DisableServiceMaintenance(serviceID(id, name))
+	return c.each(id, name, func(sid string) error {
+		return c.client.Agent().DisableServiceMaintenance(sid)
+	})
+}
+
+// Resolve returns the addresses of all instances of the service in the catalog
+func (c *Consul) Resolve(name string) ([]string, error) {
+	services, _, err := c.client.Catalog().Service(name, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, s := range services {
+		ip := s.ServiceAddress
+		if ip == "" {
+			ip = s.Address
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// Registrations returns the container services registered with the local agent
+func (c *Consul) Registrations() ([]*v1.Registration, error) {
+	services, err := c.client.Agent().Services()
+	if err != nil {
+		return nil, err
+	}
+	checks, err := c.client.Agent().Checks()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		o        []*v1.Registration
+		existing = make(map[string]*v1.Registration)
+	)
+	for sid, s := range services {
+		id, ok := s.Meta[containerMeta]
+		if !ok {
+			continue
+		}
+		key := serviceID(id, s.Service)
+		r, ok := existing[key]
+		if !ok {
+			r = &v1.Registration{
+				ID:   id,
+				Name: s.Service,
+			}
+			existing[key] = r
+			o = append(o, r)
+		}
+		// the ipv4 address is registered first
+		if strings.HasSuffix(sid, "-ipv6") {
+			r.IPs = append(r.IPs, s.Address)
+		} else {
+			r.IPs = append([]string{s.Address}, r.IPs...)
+		}
+		if check, ok := checks[maintenancePrefix+sid]; ok {
+			r.Maintenance = true
+			r.Reason = check.Notes
+		}
+	}
+	return o, nil
 }
 
-func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServiceRegistration {
+// each calls fn for the service and its ipv6 instance if it is registered
+func (c *Consul) each(id, name string, fn func(string) error) error {
+	registered, err := c.registered(id, name)
+	if err != nil {
+		return err
+	}
+	if len(registered) == 0 {
+		registered = []string{serviceID(id, name)}
+	}
+	for _, sid := range registered {
+		if err := fn(sid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registered returns the ids of the service's instances registered with the local agent
+func (c *Consul) registered(id, name string) ([]string, error) {
+	services, err := c.client.Agent().Services()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for i := 0; i < 2; i++ {
+		if _, ok := services[addressID(id, name, i)]; ok {
+			ids = append(ids, addressID(id, name, i))
+		}
+	}
+	return ids, nil
+}
+
+func (c *Consul) registration(id, sid, name, ip string, s *v1.Service) *api.AgentServiceRegistration {
 	reg := &api.AgentServiceRegistration{
-		ID:      serviceID(id, name),
+		ID:      sid,
 		Name:    name,
 		Tags:    s.Labels,
 		Port:    int(s.Port),
 		Address: ip,
+		Meta: map[string]string{
+			containerMeta: id,
+		},
 	}
 	if s.Check != nil {
 		var check api.AgentServiceCheck
@@ -61,7 +193,7 @@ func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServ
 		if s.Check.Timeout != 0 {
 			check.Timeout = fmt.Sprintf("%ds", s.Check.Timeout)
 		}
-		addr := fmt.Sprintf("%s:%d", ip, s.Port)
+		addr := net.JoinHostPort(ip, strconv.FormatInt(s.Port, 10))
 		switch s.Check.Type {
 		case "http":
 			url := s.Url
@@ -83,3 +215,11 @@ func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServ
 func serviceID(id, name string) string {
 	return fmt.Sprintf("%s-%s", id, name)
 }
+
+// addressID returns the id of the service's instance for the container's nth address
+func addressID(id, name string, n int) string {
+	if n == 0 {
+		return serviceID(id, name)
+	}
+	return serviceID(id, name) + "-ipv6"
+}
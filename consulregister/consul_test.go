@@ -0,0 +1,22 @@
+package consulregister
+
+import (
+	"os"
+	"testing"
+
+	"github.com/crosbymichael/boss/registertest"
+	"github.com/hashicorp/consul/api"
+)
+
+func TestConsul(t *testing.T) {
+	if os.Getenv("BOSS_TEST_CONSUL") == "" {
+		t.Skip("BOSS_TEST_CONSUL is not set")
+	}
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registertest.Test(New(client)); err != nil {
+		t.Fatal(err)
+	}
+}
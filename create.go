@@ -1,7 +1,6 @@
 package main
 
 import (
-	"github.com/BurntSushi/toml"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/cmd"
 	"github.com/urfave/cli"
@@ -17,8 +16,12 @@ var createCommand = cli.Command{
 		},
 	},
 	Action: func(clix *cli.Context) error {
-		var container cmd.Container
-		if _, err := toml.DecodeFile(clix.Args().First(), &container); err != nil {
+		container, err := cmd.Load(clix.Args().First())
+		if err != nil {
+			return err
+		}
+		config, err := container.Proto()
+		if err != nil {
 			return err
 		}
 		agent, err := Agent(clix)
@@ -27,7 +30,7 @@ var createCommand = cli.Command{
 		}
 		defer agent.Close()
 		_, err = agent.Create(Context(), &v1.CreateRequest{
-			Container: container.Proto(),
+			Container: config,
 			Update:    clix.Bool("update"),
 		})
 		return err
@@ -3,7 +3,10 @@ package flux
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/containerd/containerd"
@@ -23,6 +26,8 @@ const (
 	PreviousLabel    = "boss.io/revision.previous"
 	ImageLabel       = "boss.io/revision.image"
 	ContainerIDLabel = "boss.io/revision.container"
+	RemapLabel       = "boss.io/revision.remap"
+	DigestLabel      = "boss.io/revision.digest"
 )
 
 var ErrNoPreviousRevision = errors.New("no previous revision")
@@ -33,7 +38,28 @@ func WithNewSnapshot(i containerd.Image) containerd.NewContainerOpts {
 		if c.Snapshotter == "" {
 			c.Snapshotter = containerd.DefaultSnapshotter
 		}
-		r, err := create(ctx, client, i, c, c.ID, "")
+		r, err := create(ctx, client, i, c, c.ID, "", nil)
+		if err != nil {
+			return err
+		}
+		c.SnapshotKey = r.Key
+		c.Image = i.Name()
+		return nil
+	}
+}
+
+// WithNewRemappedSnapshot creates a new snapshot managed by flux with the image's
+// filesystem owned by the uid and gid mapped for a user namespace of the size
+func WithNewRemappedSnapshot(i containerd.Image, uid, gid, size uint32) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Snapshotter == "" {
+			c.Snapshotter = containerd.DefaultSnapshotter
+		}
+		r, err := create(ctx, client, i, c, c.ID, "", &Remap{
+			UID:  uid,
+			GID:  gid,
+			Size: size,
+		})
 		if err != nil {
 			return err
 		}
@@ -56,6 +82,16 @@ func WithUpgrade(i containerd.Image) containerd.UpdateContainerOpts {
 	}
 }
 
+// IsCurrent returns true if the container's current revision was created from the image
+func IsCurrent(ctx context.Context, client *containerd.Client, c containers.Container, i containerd.Image) (bool, error) {
+	info, err := client.SnapshotService(c.Snapshotter).Stat(ctx, c.SnapshotKey)
+	if err != nil {
+		return false, err
+	}
+	// revisions created before digests were saved are replaced by a revision with the digest
+	return info.Labels[DigestLabel] == i.Target().Digest.String(), nil
+}
+
 // WithRollback rolls back to the previous container's revision
 func WithRollback(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 	prev, err := previous(ctx, client, c)
@@ -112,6 +148,25 @@ func newRevision(id string) *Revision {
 	}
 }
 
+// Remap is the uid and gid offset applied to a revision's filesystem
+type Remap struct {
+	UID  uint32
+	GID  uint32
+	Size uint32
+}
+
+func (r *Remap) String() string {
+	return fmt.Sprintf("%d:%d:%d", r.UID, r.GID, r.Size)
+}
+
+func parseRemap(s string) (*Remap, error) {
+	var r Remap
+	if _, err := fmt.Sscanf(s, "%d:%d:%d", &r.UID, &r.GID, &r.Size); err != nil {
+		return nil, errors.Wrapf(err, "invalid remap %q", s)
+	}
+	return &r, nil
+}
+
 type Revision struct {
 	Timestamp time.Time
 	Key       string
@@ -122,7 +177,7 @@ func (r *Revision) Mounts() []mount.Mount {
 	return r.mounts
 }
 
-func create(ctx context.Context, client *containerd.Client, i containerd.Image, c *containers.Container, id string, previous string) (*Revision, error) {
+func create(ctx context.Context, client *containerd.Client, i containerd.Image, c *containers.Container, id string, previous string, remap *Remap) (*Revision, error) {
 	diffIDs, err := i.RootFS(ctx)
 	if err != nil {
 		return nil, err
@@ -134,11 +189,18 @@ func create(ctx context.Context, client *containerd.Client, i containerd.Image,
 	labels := map[string]string{
 		gcRoot:           r.Timestamp.Format(time.RFC3339),
 		ImageLabel:       i.Name(),
+		DigestLabel:      i.Target().Digest.String(),
 		ContainerIDLabel: id,
 	}
 	if previous != "" {
 		labels[PreviousLabel] = previous
 	}
+	if remap != nil {
+		if parent, err = remapped(ctx, client.SnapshotService(c.Snapshotter), parent, remap); err != nil {
+			return nil, err
+		}
+		labels[RemapLabel] = remap.String()
+	}
 	mounts, err := client.SnapshotService(c.Snapshotter).Prepare(ctx, r.Key, parent, snapshots.WithLabels(labels))
 	if err != nil {
 		return nil, err
@@ -147,12 +209,70 @@ func create(ctx context.Context, client *containerd.Client, i containerd.Image,
 	return r, nil
 }
 
+// remapped returns a committed snapshot of the parent with all files shifted into
+// the remapped id range, creating it if it does not exist
+func remapped(ctx context.Context, service snapshots.Snapshotter, parent string, remap *Remap) (string, error) {
+	key := fmt.Sprintf("%s-%d-%d-%d", parent, remap.UID, remap.GID, remap.Size)
+	if _, err := service.Stat(ctx, key); err == nil {
+		return key, nil
+	} else if !errdefs.IsNotFound(err) {
+		return "", err
+	}
+	active := key + "-remap"
+	mounts, err := service.Prepare(ctx, active, parent)
+	if err != nil {
+		return "", err
+	}
+	if err := mount.WithTempMount(ctx, mounts, func(root string) error {
+		return filepath.Walk(root, shiftIDs(remap))
+	}); err != nil {
+		service.Remove(ctx, active)
+		return "", err
+	}
+	if err := service.Commit(ctx, key, active); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func shiftIDs(remap *Remap) filepath.WalkFunc {
+	return func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		stat := info.Sys().(*syscall.Stat_t)
+		if stat.Uid >= remap.Size || stat.Gid >= remap.Size {
+			return errors.Errorf("%s is owned by %d:%d outside of the mapping size %d", path, stat.Uid, stat.Gid, remap.Size)
+		}
+		// lchown so that symlinks are not followed to files on the host
+		if err := os.Lchown(path, int(stat.Uid+remap.UID), int(stat.Gid+remap.GID)); err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+		// chown clears the setuid and setgid bits of executables
+		return os.Chmod(path, info.Mode())
+	}
+}
+
 func save(ctx context.Context, client *containerd.Client, updatedImage containerd.Image, c *containers.Container) (*Revision, error) {
-	snapshot, err := create(ctx, client, updatedImage, c, c.ID, c.SnapshotKey)
+	service := client.SnapshotService(c.Snapshotter)
+	sInfo, err := service.Stat(ctx, c.SnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	// keep the id mapping of the current revision
+	var remap *Remap
+	if v, ok := sInfo.Labels[RemapLabel]; ok {
+		if remap, err = parseRemap(v); err != nil {
+			return nil, err
+		}
+	}
+	snapshot, err := create(ctx, client, updatedImage, c, c.ID, c.SnapshotKey, remap)
 	if err != nil {
 		return nil, err
 	}
-	service := client.SnapshotService(c.Snapshotter)
 	// create a diff from the existing snapshot
 	diff, err := rootfs.CreateDiff(ctx, c.SnapshotKey, service, client.DiffService())
 	if err != nil {
@@ -0,0 +1,267 @@
+package ingress
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/sirupsen/logrus"
+)
+
+const resyncInterval = 30 * time.Second
+
+// New returns a new ingress proxy for the containers managed by the client
+func New(client *containerd.Client) *Proxy {
+	p := &Proxy{
+		client: client,
+	}
+	p.proxy = &httputil.ReverseProxy{
+		Director: func(r *http.Request) {},
+	}
+	return p
+}
+
+// Proxy routes http requests to running containers by host and path
+type Proxy struct {
+	client *containerd.Client
+	proxy  *httputil.ReverseProxy
+
+	mu     sync.RWMutex
+	routes []*route
+}
+
+type route struct {
+	// next is first to keep it aligned for atomic operations
+	next     uint64
+	host     string
+	path     string
+	backends []string
+}
+
+// backend returns the next backend of the route
+func (r *route) backend() string {
+	n := atomic.AddUint64(&r.next, 1)
+	return r.backends[n%uint64(len(r.backends))]
+}
+
+// ServeHTTP proxies the request to a backend of the most specific matching route
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt := p.match(r)
+	if rt == nil {
+		http.Error(w, "no route for request", http.StatusBadGateway)
+		return
+	}
+	r.URL.Scheme = "http"
+	r.URL.Host = rt.backend()
+	if _, ok := r.Header["X-Forwarded-Proto"]; !ok {
+		proto := "http"
+		if r.TLS != nil {
+			proto = "https"
+		}
+		r.Header.Set("X-Forwarded-Proto", proto)
+	}
+	p.proxy.ServeHTTP(w, r)
+}
+
+func (p *Proxy) match(r *http.Request) *route {
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	// routes are sorted from most to least specific
+	for _, rt := range p.routes {
+		if !matchHost(rt.host, host) {
+			continue
+		}
+		if matchPath(rt.path, r.URL.Path) {
+			return rt
+		}
+	}
+	return nil
+}
+
+// matchPath matches the route's path and the paths under it, /api matches /api/users but not /apiary
+func matchPath(route, path string) bool {
+	if !strings.HasPrefix(path, route) {
+		return false
+	}
+	return len(path) == len(route) || strings.HasSuffix(route, "/") || path[len(route)] == '/'
+}
+
+// matchHost matches an exact host, a wildcard subdomain such as *.example.com, or any host if empty
+func matchHost(pattern, host string) bool {
+	switch {
+	case pattern == "":
+		return true
+	case strings.HasPrefix(pattern, "*."):
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return pattern == host
+}
+
+// Run keeps the routes in sync with the running containers until the context is canceled
+func (p *Proxy) Run(ctx context.Context) error {
+	if err := p.Sync(ctx); err != nil {
+		logrus.WithError(err).Error("sync ingress routes")
+	}
+	for {
+		if err := p.watch(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logrus.WithError(err).Error("watch ingress events")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// watch syncs the routes on task and container events as these are the events
+// that services are registered and deregistered on
+func (p *Proxy) watch(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, errs := p.client.Subscribe(ctx, `topic~="/tasks/"`, `topic~="/containers/"`)
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-errs:
+			return err
+		case <-events:
+		case <-ticker.C:
+		}
+		if err := p.Sync(ctx); err != nil {
+			logrus.WithError(err).Error("sync ingress routes")
+		}
+	}
+}
+
+// Sync rebuilds the routes from the services of all running containers
+func (p *Proxy) Sync(ctx context.Context) error {
+	containers, err := p.client.Containers(ctx)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*route)
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		ip := info.Labels[opts.IPLabel]
+		if ip == "" {
+			continue
+		}
+		running, err := isRunning(ctx, c)
+		if err != nil {
+			return err
+		}
+		if !running {
+			continue
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			continue
+		}
+		for _, s := range config.Services {
+			if s.Ingress == nil {
+				continue
+			}
+			backend := net.JoinHostPort(ip, strconv.FormatInt(s.Port, 10))
+			hosts := s.Ingress.Hosts
+			if len(hosts) == 0 {
+				hosts = []string{""}
+			}
+			path := s.Ingress.Path
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+			for _, h := range hosts {
+				h = strings.ToLower(h)
+				key := h + path
+				rt, ok := byKey[key]
+				if !ok {
+					rt = &route{
+						host: h,
+						path: path,
+					}
+					byKey[key] = rt
+				}
+				rt.backends = append(rt.backends, backend)
+			}
+		}
+	}
+	routes := make([]*route, 0, len(byKey))
+	for _, rt := range byKey {
+		sort.Strings(rt.backends)
+		routes = append(routes, rt)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return moreSpecific(routes[i], routes[j])
+	})
+	p.mu.Lock()
+	p.routes = routes
+	p.mu.Unlock()
+	return nil
+}
+
+// moreSpecific orders exact hosts before wildcards before any host, then longer paths first
+func moreSpecific(a, b *route) bool {
+	if ra, rb := hostRank(a.host), hostRank(b.host); ra != rb {
+		return ra < rb
+	}
+	if len(a.host) != len(b.host) {
+		return len(a.host) > len(b.host)
+	}
+	if len(a.path) != len(b.path) {
+		return len(a.path) > len(b.path)
+	}
+	return a.host+a.path < b.host+b.path
+}
+
+func hostRank(host string) int {
+	switch {
+	case host == "":
+		return 2
+	case strings.HasPrefix(host, "*."):
+		return 1
+	}
+	return 0
+}
+
+func isRunning(ctx context.Context, c containerd.Container) (bool, error) {
+	task, err := c.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return status.Status == containerd.Running, nil
+}
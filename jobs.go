@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/urfave/cli"
+)
+
+var jobsCommand = cli.Command{
+	Name:  "jobs",
+	Usage: "list batch jobs and their recent runs",
+	Subcommands: []cli.Command{
+		jobsRunCommand,
+	},
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.Jobs(ctx, &v1.JobsRequest{
+			ID: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "ID\tSCHEDULE\tSTATUS\tSTARTED\tDURATION\tEXIT\n")
+		for _, j := range resp.Jobs {
+			if len(j.Runs) == 0 {
+				fmt.Fprintf(w, tfmt, j.ID, j.Schedule, j.Status, "-", "-", "-")
+				continue
+			}
+			for i := len(j.Runs) - 1; i >= 0; i-- {
+				r := j.Runs[i]
+				fmt.Fprintf(w, tfmt,
+					j.ID,
+					j.Schedule,
+					j.Status,
+					r.Started.Format(time.RFC3339),
+					r.Ended.Sub(r.Started).Round(time.Second),
+					fmt.Sprint(r.ExitCode),
+				)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+var jobsRunCommand = cli.Command{
+	Name:  "run",
+	Usage: "run a job now",
+	Action: func(clix *cli.Context) error {
+		var (
+			id  = clix.Args().First()
+			ctx = Context()
+		)
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.RunJob(ctx, &v1.RunJobRequest{
+			ID: id,
+		})
+		return err
+	},
+}
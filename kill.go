@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/containerd/containerd/cmd/ctr/commands"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/urfave/cli"
 )
@@ -8,20 +9,33 @@ import (
 var killCommand = cli.Command{
 	Name:  "kill",
 	Usage: "kill a running service",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "signal,s",
+			Usage: "signal to send, defaults to a graceful stop with the container's stop signal",
+		},
+	},
 	Action: func(clix *cli.Context) error {
 		var (
 			id  = clix.Args().First()
 			ctx = Context()
+			req = &v1.KillRequest{
+				ID: id,
+			}
 		)
-
+		if s := clix.String("signal"); s != "" {
+			sig, err := commands.ParseSignal(s)
+			if err != nil {
+				return err
+			}
+			req.Signal = uint32(sig)
+		}
 		agent, err := Agent(clix)
 		if err != nil {
 			return err
 		}
 		defer agent.Close()
-		_, err = agent.Kill(ctx, &v1.KillRequest{
-			ID: id,
-		})
+		_, err = agent.Kill(ctx, req)
 		return err
 	},
 }
@@ -0,0 +1,135 @@
+package kvregister
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// etcdPrefix is the prefix of the keys of the registered services
+const etcdPrefix = "/boss/services/"
+
+// NewEtcd returns a store that keeps the services in an etcd compatible cluster
+// through its v3 json gateway
+func NewEtcd(endpoints []string) Store {
+	return &etcd{
+		endpoints: endpoints,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+type etcd struct {
+	endpoints []string
+	client    *http.Client
+}
+
+// keyValue is a key value pair of the gateway, []byte fields are encoded as base64
+type keyValue struct {
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value,omitempty"`
+}
+
+type rangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+}
+
+type rangeResponse struct {
+	Kvs []keyValue `json:"kvs"`
+}
+
+func (e *etcd) Get(service, node, id string) ([]byte, error) {
+	var resp rangeResponse
+	if err := e.do("/v3/kv/range", &rangeRequest{Key: etcdKey(service, node, id)}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+func (e *etcd) Put(service, node, id string, data []byte) error {
+	return e.do("/v3/kv/put", &keyValue{Key: etcdKey(service, node, id), Value: data}, nil)
+}
+
+func (e *etcd) Delete(service, node, id string) error {
+	return e.do("/v3/kv/deleterange", &rangeRequest{Key: etcdKey(service, node, id)}, nil)
+}
+
+func (e *etcd) List(service string) ([][]byte, error) {
+	return e.list([]byte(fmt.Sprintf("%s%s/", etcdPrefix, service)))
+}
+
+func (e *etcd) All() ([][]byte, error) {
+	return e.list([]byte(etcdPrefix))
+}
+
+func (e *etcd) list(prefix []byte) ([][]byte, error) {
+	var resp rangeResponse
+	if err := e.do("/v3/kv/range", &rangeRequest{Key: prefix, RangeEnd: prefixEnd(prefix)}, &resp); err != nil {
+		return nil, err
+	}
+	var values [][]byte
+	for _, kv := range resp.Kvs {
+		values = append(values, kv.Value)
+	}
+	return values, nil
+}
+
+// do sends the request to the first endpoint that is reachable
+func (e *etcd) do(path string, req, resp interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if len(e.endpoints) == 0 {
+		return errors.New("no etcd endpoints")
+	}
+	var lastErr error
+	for _, endpoint := range e.endpoints {
+		r, err := e.client.Post(strings.TrimSuffix(endpoint, "/")+path, "application/json", bytes.NewReader(data))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return err
+		}
+		if r.StatusCode != http.StatusOK {
+			return errors.Errorf("etcd %s: %s: %s", path, r.Status, body)
+		}
+		if resp == nil {
+			return nil
+		}
+		return json.Unmarshal(body, resp)
+	}
+	return errors.Wrap(lastErr, "no etcd endpoint is reachable")
+}
+
+func etcdKey(service, node, id string) []byte {
+	return []byte(fmt.Sprintf("%s%s/%s/%s", etcdPrefix, service, node, id))
+}
+
+// prefixEnd returns the end of the range of keys with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// all keys
+	return []byte{0}
+}
@@ -0,0 +1,29 @@
+package kvregister
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/crosbymichael/boss/registertest"
+)
+
+// etcdEndpoints returns the endpoints of the etcd cluster used for the tests
+// and skips the test when BOSS_TEST_ETCD_ENDPOINTS is not set
+func etcdEndpoints(t *testing.T) []string {
+	endpoints := os.Getenv("BOSS_TEST_ETCD_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("BOSS_TEST_ETCD_ENDPOINTS is not set")
+	}
+	return strings.Split(endpoints, ",")
+}
+
+func TestEtcd(t *testing.T) {
+	if err := registertest.Test(New(NewEtcd(etcdEndpoints(t)), "node")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEtcdNodes(t *testing.T) {
+	testNodes(t, NewEtcd(etcdEndpoints(t)))
+}
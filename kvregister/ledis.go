@@ -0,0 +1,85 @@
+package kvregister
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// NewLedis returns a store that keeps each service in a hash of the cluster's
+// replicated store with a field per node and container. The pool must be connected
+// to the master store.
+func NewLedis(pool *redis.Pool) Store {
+	return &ledis{
+		pool: pool,
+	}
+}
+
+// servicesKey is the set of the names of registered services
+const servicesKey = "io.boss.register.services"
+
+type ledis struct {
+	pool *redis.Pool
+}
+
+func (l *ledis) Get(service, node, id string) ([]byte, error) {
+	data, err := redis.Bytes(l.do("HGET", serviceKey(service), instanceField(node, id)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (l *ledis) Put(service, node, id string, data []byte) error {
+	if _, err := l.do("HSET", serviceKey(service), instanceField(node, id), data); err != nil {
+		return err
+	}
+	_, err := l.do("SADD", servicesKey, service)
+	return err
+}
+
+func (l *ledis) Delete(service, node, id string) error {
+	_, err := l.do("HDEL", serviceKey(service), instanceField(node, id))
+	return err
+}
+
+func (l *ledis) List(service string) ([][]byte, error) {
+	values, err := redis.ByteSlices(l.do("HVALS", serviceKey(service)))
+	if err != nil && err != redis.ErrNil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (l *ledis) All() ([][]byte, error) {
+	services, err := redis.Strings(l.do("SMEMBERS", servicesKey))
+	if err != nil && err != redis.ErrNil {
+		return nil, err
+	}
+	var o [][]byte
+	for _, s := range services {
+		values, err := l.List(s)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, values...)
+	}
+	return o, nil
+}
+
+func (l *ledis) do(action string, args ...interface{}) (interface{}, error) {
+	conn := l.pool.Get()
+	defer conn.Close()
+	return conn.Do(action, args...)
+}
+
+func serviceKey(service string) string {
+	return fmt.Sprintf("io.boss.register.%s", service)
+}
+
+func instanceField(node, id string) string {
+	return fmt.Sprintf("%s/%s", node, id)
+}
@@ -0,0 +1,60 @@
+package kvregister
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/crosbymichael/boss/registertest"
+	"github.com/gomodule/redigo/redis"
+	lconfig "github.com/siddontang/ledisdb/config"
+	"github.com/siddontang/ledisdb/server"
+)
+
+// newLedisPool serves a ledis store from a temporary directory
+func newLedisPool(t *testing.T) (*redis.Pool, func()) {
+	dir, err := ioutil.TempDir("", "kvregister-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	l.Close()
+
+	cfg := lconfig.NewConfigDefault()
+	cfg.Addr = address
+	cfg.DataDir = dir
+	app, err := server.NewApp(cfg)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	go app.Run()
+	pool := redis.NewPool(func() (redis.Conn, error) {
+		return redis.Dial("tcp", address)
+	}, 5)
+	return pool, func() {
+		pool.Close()
+		app.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLedis(t *testing.T) {
+	pool, cleanup := newLedisPool(t)
+	defer cleanup()
+	if err := registertest.Test(New(NewLedis(pool), "node")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLedisNodes(t *testing.T) {
+	pool, cleanup := newLedisPool(t)
+	defer cleanup()
+	testNodes(t, NewLedis(pool))
+}
@@ -0,0 +1,184 @@
+package kvregister
+
+import (
+	"encoding/json"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+// Store holds the registered instances of services.
+// Instances are keyed by the node and container so that containers with the
+// same id on different nodes have their own instance.
+type Store interface {
+	// Get returns the instance of the service for the container on the node or nil if it is not registered
+	Get(service, node, id string) ([]byte, error)
+	// Put stores the instance of the service for the container on the node
+	Put(service, node, id string, data []byte) error
+	// Delete removes the instance of the service for the container on the node
+	Delete(service, node, id string) error
+	// List returns all instances of the service
+	List(service string) ([][]byte, error)
+	// All returns the instances of all services
+	All() ([][]byte, error)
+}
+
+// Instance is a container's registration of a service
+type Instance struct {
+	// Node is the node that registered the instance
+	Node   string   `json:"node"`
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	IPs    []string `json:"ips"`
+	Port   int64    `json:"port"`
+	Labels []string `json:"labels,omitempty"`
+	// Maintenance is the reason the instance is in maintenance mode
+	Maintenance string `json:"maintenance,omitempty"`
+}
+
+// New returns a register for the services of the node's containers
+func New(store Store, node string) *Register {
+	return &Register{
+		store: store,
+		node:  node,
+	}
+}
+
+// Register keeps service registrations in a key value store shared by the cluster
+type Register struct {
+	store Store
+	node  string
+}
+
+// Register registers the service at each of the container's addresses.
+// The service is in maintenance mode until its container is started,
+// the same as it is with consul.
+func (r *Register) Register(id, name string, ips []string, s *v1.Service) error {
+	return r.put(&Instance{
+		Node:        r.node,
+		ID:          id,
+		Name:        name,
+		IPs:         ips,
+		Port:        s.Port,
+		Labels:      s.Labels,
+		Maintenance: v1.MaintenanceCreated,
+	})
+}
+
+// Deregister removes the container's instance of the service registered by the node.
+// A container that moved to another node keeps the registration of the new node.
+func (r *Register) Deregister(id, name string) error {
+	return r.store.Delete(name, r.node, id)
+}
+
+// EnableMaintainance places the specific service in maintainace mode
+func (r *Register) EnableMaintainance(id, name, reason string) error {
+	i, err := r.get(id, name)
+	if err != nil {
+		return err
+	}
+	i.Maintenance = reason
+	return r.put(i)
+}
+
+// DisableMaintainance removes the specific service out of maintainace mode
+func (r *Register) DisableMaintainance(id, name string) error {
+	i, err := r.get(id, name)
+	if err != nil {
+		return err
+	}
+	i.Maintenance = ""
+	return r.put(i)
+}
+
+// Resolve returns the addresses of all instances of the service
+func (r *Register) Resolve(name string) ([]string, error) {
+	instances, err := r.Instances(name)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, i := range instances {
+		ips = append(ips, i.IPs...)
+	}
+	return ips, nil
+}
+
+// Registrations returns the services of containers registered by the node
+func (r *Register) Registrations() ([]*v1.Registration, error) {
+	values, err := r.store.All()
+	if err != nil {
+		return nil, err
+	}
+	var o []*v1.Registration
+	for _, v := range values {
+		i, err := unmarshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if i.Node != r.node {
+			continue
+		}
+		o = append(o, &v1.Registration{
+			ID:          i.ID,
+			Name:        i.Name,
+			IPs:         i.IPs,
+			Maintenance: i.Maintenance != "",
+			Reason:      i.Maintenance,
+		})
+	}
+	return o, nil
+}
+
+// Instances returns the registered instances of the service
+func (r *Register) Instances(name string) ([]*Instance, error) {
+	values, err := r.store.List(name)
+	if err != nil {
+		return nil, err
+	}
+	var instances []*Instance
+	for _, v := range values {
+		i, err := unmarshal(v)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, i)
+	}
+	return instances, nil
+}
+
+func (r *Register) get(id, name string) (*Instance, error) {
+	i, err := r.load(id, name)
+	if err != nil {
+		return nil, err
+	}
+	if i == nil {
+		return nil, errors.Errorf("service %s is not registered for %s", name, id)
+	}
+	return i, nil
+}
+
+// load returns the container's instance of the service or nil if it is not registered
+func (r *Register) load(id, name string) (*Instance, error) {
+	data, err := r.store.Get(name, r.node, id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return unmarshal(data)
+}
+
+func unmarshal(data []byte) (*Instance, error) {
+	var i Instance
+	if err := json.Unmarshal(data, &i); err != nil {
+		return nil, errors.Wrap(err, "unmarshal service instance")
+	}
+	return &i, nil
+}
+
+func (r *Register) put(i *Instance) error {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	return r.store.Put(i.Name, i.Node, i.ID, data)
+}
@@ -0,0 +1,127 @@
+package kvregister
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/registertest"
+)
+
+// memory is a store that keeps the instances in a map
+type memory struct {
+	mu        sync.Mutex
+	instances map[string][]byte
+}
+
+func newMemory() *memory {
+	return &memory{
+		instances: make(map[string][]byte),
+	}
+}
+
+func (m *memory) Get(service, node, id string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.instances[memoryKey(service, node, id)], nil
+}
+
+func (m *memory) Put(service, node, id string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.instances[memoryKey(service, node, id)] = data
+	return nil
+}
+
+func (m *memory) Delete(service, node, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.instances, memoryKey(service, node, id))
+	return nil
+}
+
+func (m *memory) List(service string) ([][]byte, error) {
+	return m.list(service + "/")
+}
+
+func (m *memory) All() ([][]byte, error) {
+	return m.list("")
+}
+
+func (m *memory) list(prefix string) ([][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for k := range m.instances {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var o [][]byte
+	for _, k := range keys {
+		o = append(o, m.instances[k])
+	}
+	return o, nil
+}
+
+func memoryKey(service, node, id string) string {
+	return service + "/" + node + "/" + id
+}
+
+func TestMemory(t *testing.T) {
+	if err := registertest.Test(New(newMemory(), "node")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryNodes(t *testing.T) {
+	testNodes(t, newMemory())
+}
+
+// testNodes checks that the same container on two nodes has an instance per node
+// and that a node only removes its own instance
+func testNodes(t *testing.T, store Store) {
+	var (
+		a       = New(store, "registertest-a")
+		b       = New(store, "registertest-b")
+		service = "registertest-nodes"
+		s       = &v1.Service{Port: 6379}
+	)
+	defer a.Deregister("redis", service)
+	defer b.Deregister("redis", service)
+	if err := a.Register("redis", service, []string{"10.199.0.2"}, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Register("redis", service, []string{"10.199.0.3"}, s); err != nil {
+		t.Fatal(err)
+	}
+	expectIPs(t, a, service, "10.199.0.2", "10.199.0.3")
+	if err := a.Deregister("redis", service); err != nil {
+		t.Fatal(err)
+	}
+	expectIPs(t, b, service, "10.199.0.3")
+	registrations, err := a.Registrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range registrations {
+		if r.Name == service {
+			t.Fatalf("node a still lists %s after deregister", service)
+		}
+	}
+}
+
+func expectIPs(t *testing.T, r *Register, service string, expected ...string) {
+	t.Helper()
+	ips, err := r.Resolve(service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(ips)
+	if strings.Join(ips, ",") != strings.Join(expected, ",") {
+		t.Fatalf("resolved %v, expected %v", ips, expected)
+	}
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -26,8 +29,8 @@ var listCommand = cli.Command{
 			return err
 		}
 		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
-		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n"
-		fmt.Fprint(w, "ID\tIMAGE\tSTATUS\tIP\tCPU\tMEMORY\tPIDS\tSIZE\tREVISIONS\n")
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n"
+		fmt.Fprint(w, "ID\tIMAGE\tSTATUS\tIP\tCPU\tMEMORY\tPIDS\tSIZE\tREVISIONS\tRESTARTS\tEXIT\tPORTS\n")
 		for _, c := range resp.Containers {
 			fmt.Fprintf(w, tfmt,
 				c.ID,
@@ -39,8 +42,26 @@ var listCommand = cli.Command{
 				fmt.Sprintf("%d/%d", c.PidUsage, c.PidLimit),
 				units.HumanSize(float64(c.FsSize)),
 				len(c.Snapshots),
+				c.Restarts,
+				c.LastExitCode,
+				ports(c.Config),
 			)
 		}
 		return w.Flush()
 	},
 }
+
+func ports(c *v1.Container) string {
+	if c == nil {
+		return ""
+	}
+	var o []string
+	for _, p := range c.Ports {
+		host := strconv.Itoa(int(p.HostPort))
+		if p.HostIP != "" {
+			host = net.JoinHostPort(p.HostIP, host)
+		}
+		o = append(o, fmt.Sprintf("%s->%d/%s", host, p.ContainerPort, p.Protocol))
+	}
+	return strings.Join(o, ",")
+}
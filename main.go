@@ -72,10 +72,10 @@ run containers like a boss`
 		deleteCommand,
 		getCommand,
 		initCommand,
+		jobsCommand,
 		killCommand,
 		listCommand,
 		migrateCommand,
-		networkCommand,
 		nodesCommand,
 		pushCommand,
 		restoreCommand,
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
 	"github.com/crosbymichael/boss/api/v1"
+	units "github.com/docker/go-units"
 	"github.com/urfave/cli"
 )
 
@@ -24,12 +30,28 @@ var migrateCommand = cli.Command{
 
 		cli.StringFlag{
 			Name:  "ref",
-			Usage: "ref name of the created checkpoint",
+			Usage: "ref name of the created checkpoint pushed through a registry, streamed to the destination agent when empty",
 		},
 		cli.StringFlag{
 			Name:  "to",
 			Usage: "destination agent",
 		},
+		cli.BoolFlag{
+			Name:  "precopy",
+			Usage: "copy the memory in rounds while the container runs and stream it to the destination agent",
+		},
+		cli.UintFlag{
+			Name:  "rounds",
+			Usage: "maximum pre-copy rounds before the final dump",
+		},
+		cli.BoolFlag{
+			Name:  "volumes",
+			Usage: "migrate the container's volumes and bind mounts with it",
+		},
+		cli.StringSliceFlag{
+			Name:  "bind-mount",
+			Usage: "restore the content of the bind mount with the source on the destination agent",
+		},
 	},
 
 	Action: func(clix *cli.Context) error {
@@ -39,14 +61,45 @@ var migrateCommand = cli.Command{
 			return err
 		}
 		defer agent.Close()
-		_, err = agent.Migrate(ctx, &v1.MigrateRequest{
-			ID:     clix.Args().First(),
-			Ref:    clix.String("ref"),
-			Stop:   clix.Bool("stop"),
-			Delete: clix.Bool("delete"),
-			To:     clix.String("to"),
-			Live:   clix.Bool("live"),
+		id := clix.Args().First()
+		if clix.Bool("volumes") {
+			if err := printEstimate(ctx, agent, id, clix.Bool("live") || clix.Bool("precopy")); err != nil {
+				return err
+			}
+		}
+		resp, err := agent.Migrate(ctx, &v1.MigrateRequest{
+			ID:         id,
+			Ref:        clix.String("ref"),
+			Stop:       clix.Bool("stop"),
+			Delete:     clix.Bool("delete"),
+			To:         clix.String("to"),
+			Live:       clix.Bool("live"),
+			Precopy:    clix.Bool("precopy"),
+			MaxRounds:  uint32(clix.Uint("rounds")),
+			Volumes:    clix.Bool("volumes"),
+			BindMounts: clix.StringSlice("bind-mount"),
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		if len(resp.Rounds) == 0 {
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "ROUND\tDIRTY\tSENT\tDURATION\n")
+		for _, r := range resp.Rounds {
+			round := strconv.Itoa(int(r.Round))
+			if r.Final {
+				round = "final"
+			}
+			fmt.Fprintf(w, tfmt,
+				round,
+				units.HumanSize(float64(r.Dirty)),
+				units.HumanSize(float64(r.Sent)),
+				r.Duration,
+			)
+		}
+		return w.Flush()
 	},
 }
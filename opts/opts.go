@@ -2,10 +2,14 @@ package opts
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/containerd"
@@ -20,13 +24,23 @@ import (
 	"github.com/gogo/protobuf/types"
 	is "github.com/opencontainers/image-spec/specs-go/v1"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
 )
 
 const (
 	CurrentConfig          = "io.boss/container"
 	LastConfig             = "io.boss/container.last"
+	JobHistory             = "io.boss/job.history"
 	IPLabel                = "io/boss/container.ip"
+	IPv6Label              = "io/boss/container.ipv6"
 	RestoreCheckpointLabel = "io/boss/restore.checkpoint"
+	restoreRefLabel        = "containerd.io/gc.ref.content.restore"
+	RestartCountLabel      = "io/boss/restart.count"
+	FailureCountLabel      = "io/boss/restart.failures"
+	ExitCodeLabel          = "io/boss/exit.code"
+	InitLabel              = "io/boss/init"
+	SidecarLabel           = "io/boss/sidecar"
 )
 
 // WithBossConfig is a containerd.NewContainerOpts for spec and container configuration
@@ -60,16 +74,15 @@ func specOpt(volumeRoot string, config *v1.Container, image containerd.Image) oc
 		oci.WithImageConfigArgs(image, config.Process.Args),
 		oci.WithHostLocaltime,
 		oci.WithNoNewPrivileges,
-		apparmor.WithDefaultProfile("boss"),
-		seccomp.WithDefaultProfile(),
+		withApparmor(config.Security),
+		withSeccomp(config.Security),
 		oci.WithEnv(config.Process.Env),
-		withMounts(config.Mounts),
-		withVolumes(volumeRoot, config.Volumes),
 		withConfigs(config.Configs),
 	}
 	if config.Network == "host" {
 		opts = append(opts, oci.WithHostHostsFile, oci.WithHostResolvconf, oci.WithHostNamespace(specs.NetworkNamespace))
-	} else if config.Network == "cni" {
+	} else if len(v1.NetworkNames(config)) > 0 {
+		// every cni network is set up in the namespace at the network path
 		opts = append(opts, withBossResolvconf, withContainerHostsFile, oci.WithLinuxNamespace(specs.LinuxNamespace{
 			Type: specs.NetworkNamespace,
 			Path: v1.NetworkPath(config.ID),
@@ -90,14 +103,218 @@ func specOpt(volumeRoot string, config *v1.Container, image containerd.Image) oc
 	if config.Process.User != nil {
 		opts = append(opts, oci.WithUIDGID(config.Process.User.Uid, config.Process.User.Gid))
 	}
+	if config.Userns != nil {
+		opts = append(opts, withUserNamespace(config.Userns))
+	}
 	if config.Readonly {
 		opts = append(opts, oci.WithRootFSReadonly())
 	}
-	// make sure this opt is run after the user has been set
-	opts = append(opts, withProcessCaps(config.Process.Capabilities))
+	// make sure these opts are run after the user and id mappings have been set
+	opts = append(opts,
+		withMounts(config.Mounts),
+		withVolumes(volumeRoot, config.Volumes),
+		withProcessCaps(config.Process.Capabilities),
+	)
 	return oci.Compose(opts...)
 }
 
+const defaultPath = "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// WithInitSpec sets the spec of an init container from the spec of the container it runs for.
+// The init container shares all mounts, volumes, and namespaces but runs the process of its own image.
+func WithInitSpec(spec *oci.Spec, config *v1.Container, image containerd.Image, args []string) containerd.NewContainerOpts {
+	var opts []oci.SpecOpts
+	if config.Process.User != nil {
+		opts = append(opts, oci.WithUIDGID(config.Process.User.Uid, config.Process.User.Gid))
+	}
+	return withChildSpec(spec, config, image, args, opts...)
+}
+
+// WithSidecarSpec sets the spec of a sidecar from the spec of the container it runs with.
+// The sidecar shares the container's namespaces and only its volumes when requested.
+func WithSidecarSpec(spec *oci.Spec, config *v1.Container, sidecar *v1.Sidecar, image containerd.Image) containerd.NewContainerOpts {
+	opts := []oci.SpecOpts{
+		oci.WithEnv(sidecar.Env),
+	}
+	if !sidecar.Volumes {
+		opts = append(opts, withoutVolumes(config))
+	}
+	return withChildSpec(spec, config, image, sidecar.Args, opts...)
+}
+
+// SidecarID returns the id of the sidecar's container
+func SidecarID(id, name string) string {
+	return fmt.Sprintf("%s-sidecar-%s", id, name)
+}
+
+func withChildSpec(spec *oci.Spec, config *v1.Container, image containerd.Image, args []string, opts ...oci.SpecOpts) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		s := *spec
+		p := *spec.Process
+		p.Env = []string{defaultPath}
+		p.User = specs.User{}
+		s.Process = &p
+		l := *spec.Linux
+		s.Linux = &l
+		o := append([]oci.SpecOpts{
+			oci.WithNamespacedCgroup(),
+			oci.WithImageConfigArgs(image, args),
+			oci.WithEnv(config.Process.Env),
+		}, opts...)
+		return containerd.WithSpec(&s, o...)(ctx, client, c)
+	}
+}
+
+func withoutVolumes(config *v1.Container) oci.SpecOpts {
+	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		exclude := make(map[string]struct{})
+		for _, m := range config.Mounts {
+			exclude[m.Destination] = struct{}{}
+		}
+		for _, v := range config.Volumes {
+			exclude[v.Destination] = struct{}{}
+		}
+		var mounts []specs.Mount
+		for _, m := range s.Mounts {
+			if _, ok := exclude[m.Destination]; ok {
+				continue
+			}
+			mounts = append(mounts, m)
+		}
+		s.Mounts = mounts
+		return nil
+	}
+}
+
+const defaultUserNamespaceSize = 65536
+
+// UserNamespaceIDs returns the host uid, gid, and size of the mapping for the namespace
+func UserNamespaceIDs(ns *v1.UserNamespace) (uid, gid, size uint32) {
+	uid, gid, size = ns.Uid, ns.Gid, ns.Size_
+	if gid == 0 {
+		gid = uid
+	}
+	if size == 0 {
+		size = defaultUserNamespaceSize
+	}
+	return uid, gid, size
+}
+
+func withUserNamespace(ns *v1.UserNamespace) oci.SpecOpts {
+	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		uid, gid, size := UserNamespaceIDs(ns)
+		if uid == 0 {
+			return errors.New("userns uid must be a subordinate id range on the host")
+		}
+		s.Linux.Namespaces = append(s.Linux.Namespaces, specs.LinuxNamespace{
+			Type: specs.UserNamespace,
+		})
+		s.Linux.UIDMappings = []specs.LinuxIDMapping{
+			{
+				ContainerID: 0,
+				HostID:      uid,
+				Size:        size,
+			},
+		}
+		s.Linux.GIDMappings = []specs.LinuxIDMapping{
+			{
+				ContainerID: 0,
+				HostID:      gid,
+				Size:        size,
+			},
+		}
+		return nil
+	}
+}
+
+// HostIDs returns the uid and gid of the process user as seen from the host
+func HostIDs(s *oci.Spec) (uid, gid int) {
+	return mapID(s.Process.User.UID, s.Linux.UIDMappings), mapID(s.Process.User.GID, s.Linux.GIDMappings)
+}
+
+func mapID(id uint32, mappings []specs.LinuxIDMapping) int {
+	for _, m := range mappings {
+		if id >= m.ContainerID && id-m.ContainerID < m.Size {
+			return int(m.HostID + id - m.ContainerID)
+		}
+	}
+	return int(id)
+}
+
+// DefaultApparmorProfile is the name of the apparmor profile generated by boss
+const DefaultApparmorProfile = "boss"
+
+func withApparmor(security *v1.Security) oci.SpecOpts {
+	if security == nil {
+		return apparmor.WithDefaultProfile(DefaultApparmorProfile)
+	}
+	switch security.Apparmor {
+	case "", "default":
+		if len(security.ApparmorProfile) > 0 {
+			return func(_ context.Context, _ oci.Client, _ *containers.Container, _ *oci.Spec) error {
+				return errors.New("apparmor profile requires the name of a custom profile")
+			}
+		}
+		return apparmor.WithDefaultProfile(DefaultApparmorProfile)
+	case "unconfined":
+		return apparmor.WithProfile("")
+	}
+	return func(ctx context.Context, client oci.Client, c *containers.Container, s *oci.Spec) error {
+		if err := LoadApparmorProfile(security); err != nil {
+			return err
+		}
+		return apparmor.WithProfile(security.Apparmor)(ctx, client, c, s)
+	}
+}
+
+// LoadApparmorProfile loads a custom apparmor profile stored with the container's config
+// into the kernel, replacing any profile already loaded under the same name
+func LoadApparmorProfile(security *v1.Security) error {
+	if security == nil || len(security.ApparmorProfile) == 0 {
+		return nil
+	}
+	f, err := ioutil.TempFile("", "boss-apparmor")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(security.ApparmorProfile)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	out, err := exec.Command("apparmor_parser", "-Kr", f.Name()).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "load apparmor profile %s: %s", security.Apparmor, out)
+	}
+	return nil
+}
+
+func withSeccomp(security *v1.Security) oci.SpecOpts {
+	if security == nil {
+		return seccomp.WithDefaultProfile()
+	}
+	if len(security.SeccompProfile) > 0 {
+		return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+			var profile specs.LinuxSeccomp
+			if err := json.Unmarshal(security.SeccompProfile, &profile); err != nil {
+				return errors.Wrapf(err, "decode seccomp profile %s", security.Seccomp)
+			}
+			s.Linux.Seccomp = &profile
+			return nil
+		}
+	}
+	switch security.Seccomp {
+	case "", "default":
+		return seccomp.WithDefaultProfile()
+	case "unconfined":
+		return oci.WithSeccompUnconfined
+	}
+	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		return errors.Errorf("seccomp profile %s has no content", security.Seccomp)
+	}
+}
+
 func withProcessCaps(capabilities []string) oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
 		set := make(map[string]struct{})
@@ -151,43 +368,179 @@ func toGpuCaps(ss []string) (o []nvidia.Capability) {
 
 func withResources(r *v1.Resources) oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		resources, err := Resources(r)
+		if err != nil {
+			return err
+		}
+		// keep the device rules from the default spec
+		s.Linux.Resources.Memory = resources.Memory
+		s.Linux.Resources.CPU = resources.CPU
+		s.Linux.Resources.Pids = resources.Pids
+		s.Linux.Resources.BlockIO = resources.BlockIO
+		if r.Score != 0 {
+			score := int(r.Score)
+			s.Process.OOMScoreAdj = &score
+		}
+		if r.NoFile > 0 {
+			setRlimit(s, specs.POSIXRlimit{
+				Type: "RLIMIT_NOFILE",
+				Hard: r.NoFile,
+				Soft: r.NoFile,
+			})
+		}
+		for _, l := range r.Rlimits {
+			setRlimit(s, specs.POSIXRlimit{
+				Type: rlimitType(l.Type),
+				Hard: l.Hard,
+				Soft: l.Soft,
+			})
+		}
+		return nil
+	}
+}
+
+// Resources returns the cgroup resources for the container's config so that they
+// can be used for both spec generation and live updates of a running task
+func Resources(r *v1.Resources) (*specs.LinuxResources, error) {
+	var resources specs.LinuxResources
+	if r == nil {
+		return &resources, nil
+	}
+	if r.Memory > 0 || r.MemoryReservation > 0 || r.MemorySwap > 0 {
+		var memory specs.LinuxMemory
 		if r.Memory > 0 {
 			limit := r.Memory * 1024 * 1024
-			s.Linux.Resources.Memory = &specs.LinuxMemory{
-				Limit: &limit,
-			}
+			memory.Limit = &limit
+		}
+		if r.MemoryReservation > 0 {
+			reservation := r.MemoryReservation * 1024 * 1024
+			memory.Reservation = &reservation
+		}
+		if r.MemorySwap > 0 {
+			swap := r.MemorySwap * 1024 * 1024
+			memory.Swap = &swap
+		}
+		resources.Memory = &memory
+	}
+	if r.Cpus > 0 || r.CpusetCpus != "" || r.CpusetMems != "" {
+		cpu := specs.LinuxCPU{
+			Cpus: r.CpusetCpus,
+			Mems: r.CpusetMems,
 		}
 		if r.Cpus > 0 {
 			period := uint64(100000)
 			quota := int64(r.Cpus * 100000.0)
-			s.Linux.Resources.CPU = &specs.LinuxCPU{
-				Quota:  &quota,
-				Period: &period,
-			}
+			cpu.Quota = &quota
+			cpu.Period = &period
 		}
-		if r.Score != 0 {
-			score := int(r.Score)
-			s.Process.OOMScoreAdj = &score
+		resources.CPU = &cpu
+	}
+	if r.Pids > 0 {
+		resources.Pids = &specs.LinuxPids{
+			Limit: r.Pids,
 		}
-		if r.NoFile > 0 {
-			s.Process.Rlimits = []specs.POSIXRlimit{
-				{
-					Type: "RLIMIT_NOFILE",
-					Hard: r.NoFile,
-					Soft: r.NoFile,
-				},
-			}
+	}
+	blkio, err := blockIO(r)
+	if err != nil {
+		return nil, err
+	}
+	resources.BlockIO = blkio
+	return &resources, nil
+}
+
+func blockIO(r *v1.Resources) (*specs.LinuxBlockIO, error) {
+	if r.BlkioWeight == 0 && len(r.BlkioWeightDevices) == 0 &&
+		len(r.DeviceReadBps) == 0 && len(r.DeviceWriteBps) == 0 &&
+		len(r.DeviceReadIops) == 0 && len(r.DeviceWriteIops) == 0 {
+		return nil, nil
+	}
+	var blkio specs.LinuxBlockIO
+	if r.BlkioWeight > 0 {
+		weight := uint16(r.BlkioWeight)
+		blkio.Weight = &weight
+	}
+	for _, d := range r.BlkioWeightDevices {
+		major, minor, err := DeviceNumbers(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		weight := uint16(d.Weight)
+		var wd specs.LinuxWeightDevice
+		wd.Major = major
+		wd.Minor = minor
+		wd.Weight = &weight
+		blkio.WeightDevice = append(blkio.WeightDevice, wd)
+	}
+	var err error
+	if blkio.ThrottleReadBpsDevice, err = throttleDevices(r.DeviceReadBps); err != nil {
+		return nil, err
+	}
+	if blkio.ThrottleWriteBpsDevice, err = throttleDevices(r.DeviceWriteBps); err != nil {
+		return nil, err
+	}
+	if blkio.ThrottleReadIOPSDevice, err = throttleDevices(r.DeviceReadIops); err != nil {
+		return nil, err
+	}
+	if blkio.ThrottleWriteIOPSDevice, err = throttleDevices(r.DeviceWriteIops); err != nil {
+		return nil, err
+	}
+	return &blkio, nil
+}
+
+func throttleDevices(devices []*v1.ThrottleDevice) (o []specs.LinuxThrottleDevice, err error) {
+	for _, d := range devices {
+		major, minor, err := DeviceNumbers(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		var td specs.LinuxThrottleDevice
+		td.Major = major
+		td.Minor = minor
+		td.Rate = d.Rate
+		o = append(o, td)
+	}
+	return o, nil
+}
+
+// DeviceNumbers returns the major and minor numbers of the block device
+func DeviceNumbers(path string) (int64, int64, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return 0, 0, errors.Wrapf(err, "stat device %s", path)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return 0, 0, errors.Errorf("%s is not a block device", path)
+	}
+	return int64(unix.Major(uint64(stat.Rdev))), int64(unix.Minor(uint64(stat.Rdev))), nil
+}
+
+// setRlimit replaces the rlimit of the same type or adds it to the spec
+func setRlimit(s *oci.Spec, limit specs.POSIXRlimit) {
+	for i, l := range s.Process.Rlimits {
+		if l.Type == limit.Type {
+			s.Process.Rlimits[i] = limit
+			return
 		}
-		return nil
 	}
+	s.Process.Rlimits = append(s.Process.Rlimits, limit)
+}
+
+// rlimitType allows rlimits to be specified as "nproc" or "RLIMIT_NPROC"
+func rlimitType(t string) string {
+	t = strings.ToUpper(t)
+	if !strings.HasPrefix(t, "RLIMIT_") {
+		t = "RLIMIT_" + t
+	}
+	return t
 }
 
 func withMounts(mounts []*v1.Mount) oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		uid, gid := HostIDs(s)
 		for _, cm := range mounts {
 			if cm.Type == "bind" {
 				// create source if it does not exist
-				if err := createHostDir(cm.Source, int(s.Process.User.UID), int(s.Process.User.GID)); err != nil {
+				if err := createHostDir(cm.Source, uid, gid); err != nil {
 					return err
 				}
 			}
@@ -220,12 +573,13 @@ func createHostDir(path string, uid, gid int) error {
 
 func withVolumes(root string, volumes []*v1.Volume) oci.SpecOpts {
 	return func(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
+		uid, gid := HostIDs(s)
 		for _, cm := range volumes {
 			if root == "" {
 				return errors.New("no volume_root specified")
 			}
 			source := filepath.Join(root, cm.ID)
-			if err := createHostDir(source, int(s.Process.User.UID), int(s.Process.User.GID)); err != nil {
+			if err := createHostDir(source, uid, gid); err != nil {
 				return err
 			}
 			opts := []string{"bind"}
@@ -334,17 +688,209 @@ func UnmarshalConfig(any *types.Any) (*v1.Container, error) {
 	return c, nil
 }
 
-// WithIP sets the ip on the container
-func WithIP(ip string) containerd.UpdateContainerOpts {
+// WithIPs sets the addresses of the container's primary network.
+// The first address is the container's ip and an ipv6 address is also recorded on its own.
+func WithIPs(ips []string) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		delete(c.Labels, IPLabel)
+		delete(c.Labels, IPv6Label)
+		if len(ips) > 0 {
+			c.Labels[IPLabel] = ips[0]
+		}
+		if ip := ipv6(ips); ip != "" {
+			c.Labels[IPv6Label] = ip
+		}
+		return nil
+	}
+}
+
+// IPs returns the addresses of the container's primary network with the container's ip first
+func IPs(labels map[string]string) []string {
+	return addresses(labels[IPLabel], labels[IPv6Label])
+}
+
+// NetworkIPLabel returns the label for the container's ip on the network
+func NetworkIPLabel(network string) string {
+	return fmt.Sprintf("io/boss/network.%s.ip", network)
+}
+
+// NetworkIPv6Label returns the label for the container's ipv6 address on the network
+func NetworkIPv6Label(network string) string {
+	return fmt.Sprintf("io/boss/network.%s.ipv6", network)
+}
+
+// ReservedIPLabel returns the label for the address reserved for the container on the network
+func ReservedIPLabel(network string) string {
+	return fmt.Sprintf("io/boss/network.%s.reserved", network)
+}
+
+// WithReservedIPs records the addresses reserved for the container on each network
+// replacing any previous reservations
+func WithReservedIPs(ips map[string]string) func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		for k := range c.Labels {
+			if strings.HasPrefix(k, "io/boss/network.") && strings.HasSuffix(k, ".reserved") {
+				delete(c.Labels, k)
+			}
+		}
+		for network, ip := range ips {
+			c.Labels[ReservedIPLabel(network)] = ip
+		}
+		return nil
+	}
+}
+
+// WithNetworkIPs records the container's addresses on each network it is attached to
+func WithNetworkIPs(ips map[string][]string) containerd.UpdateContainerOpts {
 	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 		if c.Labels == nil {
 			c.Labels = make(map[string]string)
 		}
-		c.Labels[IPLabel] = ip
+		for network, addrs := range ips {
+			delete(c.Labels, NetworkIPLabel(network))
+			delete(c.Labels, NetworkIPv6Label(network))
+			if len(addrs) > 0 {
+				c.Labels[NetworkIPLabel(network)] = addrs[0]
+			}
+			if ip := ipv6(addrs); ip != "" {
+				c.Labels[NetworkIPv6Label(network)] = ip
+			}
+		}
 		return nil
 	}
 }
 
+// NetworkAddresses returns the container's addresses on the network.
+// The primary network falls back to the container's ip for containers
+// created before addresses were recorded per network.
+func NetworkAddresses(labels map[string]string, network string, primary bool) []string {
+	ips := addresses(labels[NetworkIPLabel(network)], labels[NetworkIPv6Label(network)])
+	if len(ips) == 0 && primary {
+		return IPs(labels)
+	}
+	return ips
+}
+
+// NetworkIPs returns the container's addresses on each network it is attached to
+func NetworkIPs(labels map[string]string, config *v1.Container) map[string]string {
+	ips := make(map[string]string)
+	for _, network := range config.Networks {
+		if addrs := NetworkAddresses(labels, network, false); len(addrs) > 0 {
+			ips[network] = strings.Join(addrs, ",")
+		}
+	}
+	if len(ips) == 0 && labels[IPLabel] != "" && config.Network != "" {
+		ips[config.Network] = strings.Join(IPs(labels), ",")
+	}
+	return ips
+}
+
+// addresses returns the ip followed by the ipv6 address when it is not the same address
+func addresses(ip, v6 string) (o []string) {
+	if ip != "" {
+		o = append(o, ip)
+	}
+	if v6 != "" && v6 != ip {
+		o = append(o, v6)
+	}
+	return o
+}
+
+func ipv6(ips []string) string {
+	for _, ip := range ips {
+		if p := net.ParseIP(ip); p != nil && p.To4() == nil {
+			return ip
+		}
+	}
+	return ""
+}
+
+// maxJobRuns is the number of runs kept in a job's history
+const maxJobRuns = 50
+
+// WithJobRun adds the run to the job's history
+func WithJobRun(run *v1.JobRun) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		history, err := GetJobHistoryFromInfo(*c)
+		if err != nil {
+			return err
+		}
+		history.Runs = append(history.Runs, run)
+		if len(history.Runs) > maxJobRuns {
+			history.Runs = history.Runs[len(history.Runs)-maxJobRuns:]
+		}
+		any, err := typeurl.MarshalAny(history)
+		if err != nil {
+			return err
+		}
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]types.Any)
+		}
+		c.Extensions[JobHistory] = *any
+		return nil
+	}
+}
+
+// GetJobHistoryFromInfo returns the runs of a job, oldest first
+func GetJobHistoryFromInfo(info containers.Container) (*v1.JobHistory, error) {
+	d, ok := info.Extensions[JobHistory]
+	if !ok {
+		return &v1.JobHistory{}, nil
+	}
+	v, err := typeurl.UnmarshalAny(&d)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*v1.JobHistory), nil
+}
+
+// WithRestart increments the restart count of the container if its task has exited before
+func WithRestart(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	if _, ok := c.Labels[ExitCodeLabel]; !ok {
+		return nil
+	}
+	c.Labels[RestartCountLabel] = strconv.FormatUint(LabelUint(c.Labels, RestartCountLabel)+1, 10)
+	return nil
+}
+
+// WithTaskExit records the exit status of the container's task.
+// Consecutive failures are counted until the task exits cleanly or reset is set.
+func WithTaskExit(code int, reset bool) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		c.Labels[ExitCodeLabel] = strconv.Itoa(code)
+		failures := LabelUint(c.Labels, FailureCountLabel) + 1
+		if code == 0 || reset {
+			failures = 0
+		}
+		c.Labels[FailureCountLabel] = strconv.FormatUint(failures, 10)
+		return nil
+	}
+}
+
+// WithoutFailures resets the consecutive failures of the container's task
+func WithoutFailures(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	delete(c.Labels, FailureCountLabel)
+	return nil
+}
+
+// LabelUint returns the label's value as an uint64 or 0 if it is not set
+func LabelUint(labels map[string]string, key string) uint64 {
+	v, err := strconv.ParseUint(labels[key], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
 func WithRestore(m *is.Descriptor) containerd.NewContainerOpts {
 	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 		if c.Extensions == nil {
@@ -360,6 +906,11 @@ func WithRestore(m *is.Descriptor) containerd.NewContainerOpts {
 			return err
 		}
 		c.Extensions[RestoreCheckpointLabel] = *any
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		// keep the checkpoint until the task is restored when its image is removed
+		c.Labels[restoreRefLabel] = m.Digest.String()
 		return nil
 	}
 }
@@ -369,6 +920,7 @@ func WithoutRestore(ctx context.Context, client *containerd.Client, c *container
 		c.Extensions = make(map[string]types.Any)
 	}
 	delete(c.Extensions, RestoreCheckpointLabel)
+	delete(c.Labels, restoreRefLabel)
 	return nil
 }
 
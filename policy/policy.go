@@ -0,0 +1,199 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+// table is the nftables table in the container's network namespace
+const table = "inet boss"
+
+// Resolver returns the addresses of a service
+type Resolver func(service string) ([]string, error)
+
+// Rules renders the nftables ruleset for the policy.
+// Service names are resolved to the addresses of their instances.
+func Rules(p *v1.Policy, resolve Resolver) (string, error) {
+	var b bytes.Buffer
+	// creating the table before deleting it makes the delete work when it does not exist
+	// and replaces the ruleset in a single transaction
+	fmt.Fprintf(&b, "table %s\ndelete table %s\ntable %s {\n", table, table, table)
+	if len(p.Ingress) > 0 {
+		if err := chain(&b, "input", "saddr", "iif", p.Ingress, resolve); err != nil {
+			return "", errors.Wrap(err, "ingress")
+		}
+	}
+	if len(p.Egress) > 0 {
+		if err := chain(&b, "output", "daddr", "oif", p.Egress, resolve); err != nil {
+			return "", errors.Wrap(err, "egress")
+		}
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// Apply replaces the rules in the network namespace
+func Apply(netns, rules string) error {
+	return nft(netns, rules)
+}
+
+// Remove removes all rules from the network namespace
+func Remove(netns string) error {
+	return nft(netns, fmt.Sprintf("table %s\ndelete table %s\n", table, table))
+}
+
+func chain(b *bytes.Buffer, name, match, iface string, rules []*v1.PolicyRule, resolve Resolver) error {
+	fmt.Fprintf(b, "\tchain %s {\n", name)
+	fmt.Fprintf(b, "\t\ttype filter hook %s priority 0; policy drop;\n", name)
+	b.WriteString("\t\tct state established,related accept\n")
+	fmt.Fprintf(b, "\t\t%s lo accept\n", iface)
+	// neighbor discovery and dhcp leases are required for the container's interfaces to work
+	b.WriteString("\t\tmeta l4proto ipv6-icmp accept\n")
+	b.WriteString("\t\tudp sport { 67, 68 } udp dport { 67, 68 } accept\n")
+	for _, r := range rules {
+		lines, err := rule(match, r, resolve)
+		if err != nil {
+			return err
+		}
+		for _, l := range lines {
+			fmt.Fprintf(b, "\t\t%s\n", l)
+		}
+	}
+	b.WriteString("\t}\n")
+	return nil
+}
+
+func rule(match string, r *v1.PolicyRule, resolve Resolver) ([]string, error) {
+	addrs, err := addresses(r, resolve)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		lines   []string
+		sources []string
+	)
+	if len(r.Services) == 0 && len(r.CIDRs) == 0 {
+		// any address
+		sources = []string{""}
+	} else {
+		for _, family := range []string{"ip", "ip6"} {
+			if set := addrs[family]; len(set) > 0 {
+				sources = append(sources, fmt.Sprintf("%s %s { %s } ", family, match, strings.Join(set, ", ")))
+			}
+		}
+	}
+	var ports []string
+	for _, p := range r.Ports {
+		ports = append(ports, strconv.Itoa(int(p)))
+	}
+	protocols := []string{r.Protocol}
+	if r.Protocol == "" && len(ports) > 0 {
+		protocols = []string{"tcp", "udp"}
+	}
+	for _, s := range sources {
+		if len(ports) == 0 {
+			lines = append(lines, s+"accept")
+			continue
+		}
+		for _, proto := range protocols {
+			lines = append(lines, fmt.Sprintf("%s%s dport { %s } accept", s, proto, strings.Join(ports, ", ")))
+		}
+	}
+	return lines, nil
+}
+
+// addresses returns the rule's cidrs and the addresses of its services by family
+func addresses(r *v1.PolicyRule, resolve Resolver) (map[string][]string, error) {
+	all := append([]string{}, r.CIDRs...)
+	for _, s := range r.Services {
+		ips, err := resolve(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve service %s", s)
+		}
+		all = append(all, ips...)
+	}
+	var nets []*net.IPNet
+	for _, a := range all {
+		n, err := parseNet(a)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	o := make(map[string][]string)
+	for i, n := range nets {
+		// nftables rejects overlapping elements in a set
+		if covered(n, i, nets) {
+			continue
+		}
+		family := "ip"
+		if n.IP.To4() == nil {
+			family = "ip6"
+		}
+		o[family] = append(o[family], element(n))
+	}
+	// a stable order lets the agent skip rules that are unchanged
+	for _, set := range o {
+		sort.Strings(set)
+	}
+	return o, nil
+}
+
+// parseNet parses a cidr or a single address as a host network
+func parseNet(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, errors.Errorf("invalid address %q", s)
+	}
+	if ip4 := n.IP.To4(); ip4 != nil {
+		n.IP = ip4
+	}
+	return n, nil
+}
+
+// covered returns true if the network is within another network of the list
+// or is a duplicate of an earlier one
+func covered(n *net.IPNet, i int, nets []*net.IPNet) bool {
+	ones, _ := n.Mask.Size()
+	for j, o := range nets {
+		if i == j || len(o.IP) != len(n.IP) || !o.Contains(n.IP) {
+			continue
+		}
+		oones, _ := o.Mask.Size()
+		if oones < ones || (oones == ones && j < i) {
+			return true
+		}
+	}
+	return false
+}
+
+func element(n *net.IPNet) string {
+	if ones, bits := n.Mask.Size(); ones == bits {
+		return n.IP.String()
+	}
+	return n.String()
+}
+
+func nft(netns, rules string) error {
+	cmd := exec.Command("nsenter", "--net="+netns, "nft", "-f", "-")
+	cmd.Stdin = strings.NewReader(rules)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrap(err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package registertest checks that implementations of v1.Register behave the same
+package registertest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+// Test registers, resolves and removes services with the register returning
+// the first behavior that does not match the consul register.
+// The services use unique names so it is safe to run against a live cluster.
+func Test(r v1.Register) (err error) {
+	var (
+		suffix  = fmt.Sprintf("%d", time.Now().UnixNano())
+		name    = "registertest-" + suffix
+		first   = "registertest-a-" + suffix
+		second  = "registertest-b-" + suffix
+		service = &v1.Service{
+			Port:   8080,
+			Labels: []string{"registertest"},
+		}
+	)
+	defer func() {
+		r.Deregister(first, name)
+		r.Deregister(second, name)
+	}()
+	if err := expect(r, name); err != nil {
+		return errors.Wrap(err, "unknown service")
+	}
+	if err := r.Register(first, name, []string{"10.199.0.2", "fd00:199::2"}, service); err != nil {
+		return errors.Wrap(err, "register")
+	}
+	if err := expect(r, name, "10.199.0.2", "fd00:199::2"); err != nil {
+		return errors.Wrap(err, "register every address")
+	}
+	if err := expectRegistration(r, first, name, true); err != nil {
+		return errors.Wrap(err, "list registrations")
+	}
+	if err := r.Register(first, name, []string{"10.199.0.3"}, service); err != nil {
+		return errors.Wrap(err, "register again")
+	}
+	if err := expect(r, name, "10.199.0.3"); err != nil {
+		return errors.Wrap(err, "replace addresses")
+	}
+	if err := r.Register(second, name, []string{"10.199.0.4"}, service); err != nil {
+		return errors.Wrap(err, "register second container")
+	}
+	if err := expect(r, name, "10.199.0.3", "10.199.0.4"); err != nil {
+		return errors.Wrap(err, "resolve all containers")
+	}
+	if err := r.DisableMaintainance(first, name); err != nil {
+		return errors.Wrap(err, "disable maintenance")
+	}
+	if err := r.EnableMaintainance(first, name, "registertest"); err != nil {
+		return errors.Wrap(err, "enable maintenance")
+	}
+	if err := expectRegistration(r, first, name, true); err != nil {
+		return errors.Wrap(err, "list registrations in maintenance")
+	}
+	// instances in maintenance are still resolved so that policies keep them
+	if err := expect(r, name, "10.199.0.3", "10.199.0.4"); err != nil {
+		return errors.Wrap(err, "resolve in maintenance")
+	}
+	if err := r.Deregister(first, name); err != nil {
+		return errors.Wrap(err, "deregister")
+	}
+	if err := expect(r, name, "10.199.0.4"); err != nil {
+		return errors.Wrap(err, "deregister one container")
+	}
+	if err := expectRegistration(r, first, name, false); err != nil {
+		return errors.Wrap(err, "list registrations after deregister")
+	}
+	if err := r.Deregister(second, name); err != nil {
+		return errors.Wrap(err, "deregister second container")
+	}
+	if err := expect(r, name); err != nil {
+		return errors.Wrap(err, "deregister all containers")
+	}
+	return nil
+}
+
+// expect returns an error if the service does not resolve to exactly the addresses
+func expect(r v1.Register, name string, ips ...string) error {
+	// registrations may take a moment to show up in a cluster's catalog
+	var (
+		got []string
+		err error
+	)
+	for i := 0; i < 10; i++ {
+		if got, err = r.Resolve(name); err != nil {
+			return err
+		}
+		if equal(got, ips) {
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return errors.Errorf("resolved %v, expected %v", got, ips)
+}
+
+// expectRegistration returns an error if the container's service is not listed in maintenance
+// when it should exist, or is listed when it should not
+func expectRegistration(r v1.Register, id, name string, exists bool) error {
+	registrations, err := r.Registrations()
+	if err != nil {
+		return err
+	}
+	for _, reg := range registrations {
+		if reg.ID != id || reg.Name != name {
+			continue
+		}
+		if !exists {
+			return errors.Errorf("%s of %s is still registered", name, id)
+		}
+		if !reg.Maintenance {
+			return errors.Errorf("%s of %s is not in maintenance", name, id)
+		}
+		return nil
+	}
+	if exists {
+		return errors.Errorf("%s of %s is not registered", name, id)
+	}
+	return nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
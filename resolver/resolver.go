@@ -0,0 +1,215 @@
+package resolver
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/sirupsen/logrus"
+)
+
+// ttl of the answers for the cluster's records
+const ttl = 5
+
+// Records are the addresses of the cluster's containers and the instances of their services
+type Records struct {
+	// Containers are the addresses of each container by <id>.<node> and by id
+	// for all nodes running a container with the id
+	Containers map[string][]string `json:"containers"`
+	// Services are the instances of each service by name
+	Services map[string][]Instance `json:"services"`
+}
+
+// Instance is a container serving a service on a port
+type Instance struct {
+	Container string `json:"container"`
+	Node      string `json:"node"`
+	Port      int64  `json:"port"`
+}
+
+// Host returns the name of the instance's container within the domain
+func (i Instance) Host() string {
+	return Host(i.Container, i.Node)
+}
+
+// Host returns the name of the container on the node within the domain
+func Host(container, node string) string {
+	return container + "." + node
+}
+
+// Merge adds the records to r
+func (r *Records) Merge(o *Records) {
+	if r.Containers == nil {
+		r.Containers = make(map[string][]string)
+	}
+	if r.Services == nil {
+		r.Services = make(map[string][]Instance)
+	}
+	for name, ips := range o.Containers {
+		r.Containers[name] = append(r.Containers[name], ips...)
+	}
+	for name, instances := range o.Services {
+		r.Services[name] = append(r.Services[name], instances...)
+	}
+}
+
+// Source returns the current records of the cluster
+type Source func() (*Records, error)
+
+// New returns a dns server answering for <service>.service.<domain>, <container>.<node>.<domain>,
+// and <container>.<domain>
+// from the source and forwarding all other queries to the upstreams
+func New(domain string, upstreams []string, source Source) *Server {
+	return &Server{
+		domain:    dns.Fqdn(strings.ToLower(domain)),
+		upstreams: upstreams,
+		source:    source,
+		client: &dns.Client{
+			Timeout: 2 * time.Second,
+		},
+	}
+}
+
+// Server is a dns server for the cluster's domain
+type Server struct {
+	domain    string
+	upstreams []string
+	source    Source
+	client    *dns.Client
+}
+
+// Serve answers queries over udp and tcp on the address
+func (s *Server) Serve(address string) error {
+	errCh := make(chan error, 2)
+	for _, network := range []string{"udp", "tcp"} {
+		server := &dns.Server{
+			Addr:    address,
+			Net:     network,
+			Handler: s,
+		}
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+	return <-errCh
+}
+
+// ServeDNS answers a query
+func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	if len(r.Question) == 0 {
+		dns.HandleFailed(w, r)
+		return
+	}
+	q := r.Question[0]
+	name := strings.ToLower(q.Name)
+	if !dns.IsSubDomain(s.domain, name) {
+		s.forward(w, r)
+		return
+	}
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Authoritative = true
+	records, err := s.source()
+	if err != nil {
+		logrus.WithError(err).Error("load dns records")
+		m.SetRcode(r, dns.RcodeServerFailure)
+		w.WriteMsg(m)
+		return
+	}
+	if !s.answer(m, q, strings.TrimSuffix(name, "."+s.domain), records) {
+		m.SetRcode(r, dns.RcodeNameError)
+	}
+	w.WriteMsg(m)
+}
+
+// answer adds the answers for the name within the domain, returning false if the name does not exist
+func (s *Server) answer(m *dns.Msg, q dns.Question, name string, records *Records) bool {
+	if service := strings.TrimSuffix(name, ".service"); service != name {
+		instances, ok := records.Services[service]
+		if !ok {
+			return false
+		}
+		sort.Slice(instances, func(i, j int) bool {
+			return instances[i].Host() < instances[j].Host()
+		})
+		for _, i := range instances {
+			var (
+				host   = i.Host()
+				target = dns.Fqdn(host + "." + s.domain)
+			)
+			switch q.Qtype {
+			case dns.TypeSRV:
+				m.Answer = append(m.Answer, &dns.SRV{
+					Hdr:      header(q.Name, dns.TypeSRV),
+					Priority: 1,
+					Weight:   1,
+					Port:     uint16(i.Port),
+					Target:   target,
+				})
+				m.Extra = append(m.Extra, addresses(target, dns.TypeANY, records.Containers[host])...)
+			default:
+				m.Answer = append(m.Answer, addresses(q.Name, q.Qtype, records.Containers[host])...)
+			}
+		}
+		return true
+	}
+	ips, ok := records.Containers[name]
+	if !ok {
+		return false
+	}
+	m.Answer = append(m.Answer, addresses(q.Name, q.Qtype, ips)...)
+	return true
+}
+
+// forward sends the query to the upstreams returning the first answer
+func (s *Server) forward(w dns.ResponseWriter, r *dns.Msg) {
+	for _, u := range s.upstreams {
+		resp, _, err := s.client.Exchange(r, u)
+		if err != nil {
+			logrus.WithError(err).WithField("upstream", u).Debug("forward dns query")
+			continue
+		}
+		w.WriteMsg(resp)
+		return
+	}
+	m := new(dns.Msg)
+	m.SetRcode(r, dns.RcodeServerFailure)
+	w.WriteMsg(m)
+}
+
+// addresses returns the A and AAAA records of the ips matching the query type
+func addresses(name string, qtype uint16, ips []string) (o []dns.RR) {
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			continue
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			if qtype == dns.TypeA || qtype == dns.TypeANY {
+				o = append(o, &dns.A{
+					Hdr: header(name, dns.TypeA),
+					A:   ip4,
+				})
+			}
+			continue
+		}
+		if qtype == dns.TypeAAAA || qtype == dns.TypeANY {
+			o = append(o, &dns.AAAA{
+				Hdr:  header(name, dns.TypeAAAA),
+				AAAA: ip,
+			})
+		}
+	}
+	return o
+}
+
+func header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  dns.ClassINET,
+		Ttl:    ttl,
+	}
+}
@@ -13,6 +13,10 @@ var restoreCommand = cli.Command{
 			Name:  "live",
 			Usage: "enable live restore(criu must be installed)",
 		},
+		cli.StringSliceFlag{
+			Name:  "bind-mount",
+			Usage: "restore the content of the bind mount with the source from the checkpoint",
+		},
 	},
 	Action: func(clix *cli.Context) error {
 		ctx := Context()
@@ -22,8 +26,9 @@ var restoreCommand = cli.Command{
 		}
 		defer agent.Close()
 		_, err = agent.Restore(ctx, &v1.RestoreRequest{
-			Ref:  clix.Args().First(),
-			Live: clix.Bool("live"),
+			Ref:        clix.Args().First(),
+			Live:       clix.Bool("live"),
+			BindMounts: clix.StringSlice("bind-mount"),
 		})
 		return err
 	},
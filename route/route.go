@@ -1,48 +1,185 @@
 package route
 
 import (
+	"fmt"
 	"net"
-	"os/exec"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
+// Interface is the default macvlan device on the host
 const Interface = "mvlan0"
 
-func Create(iface, address string) (err error) {
+// ErrDeviceNotFound is returned when a device does not exist on the host
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Error is returned when a route operation fails
+type Error struct {
+	Op      string
+	Device  string
+	Address string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Address == "" {
+		return fmt.Sprintf("%s %s: %v", e.Op, e.Device, e.Err)
+	}
+	return fmt.Sprintf("%s %s on %s: %v", e.Op, e.Address, e.Device, e.Err)
+}
+
+// Cause returns the underlying error
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// IsDeviceNotFound returns true if the error is caused by a missing device
+func IsDeviceNotFound(err error) bool {
+	return errors.Cause(err) == ErrDeviceNotFound
+}
+
+// Create creates the macvlan device on the host for routing to containers
+func Create(device, iface, address string) (err error) {
 	// don't create if it already exists
-	if _, err := net.InterfaceByName(Interface); err == nil {
+	if _, err := netlink.LinkByName(device); err == nil {
 		return nil
 	}
+	parent, err := link(iface)
+	if err != nil {
+		return &Error{Op: "create", Device: device, Err: err}
+	}
+	addr, err := netlink.ParseAddr(withMask(address))
+	if err != nil {
+		return &Error{Op: "create", Device: device, Address: address, Err: err}
+	}
+	mv := &netlink.Macvlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        device,
+			ParentIndex: parent.Attrs().Index,
+		},
+		Mode: netlink.MACVLAN_MODE_BRIDGE,
+	}
+	if err := netlink.LinkAdd(mv); err != nil {
+		return &Error{Op: "create", Device: device, Err: err}
+	}
 	defer func() {
 		if err != nil {
-			ip("link", "del", Interface)
+			netlink.LinkDel(mv)
 		}
 	}()
-	if err := ip("link", "add", "link", iface, Interface, "type", "macvlan", "mode", "bridge"); err != nil {
-		return err
+	if err := netlink.AddrReplace(mv, addr); err != nil {
+		return &Error{Op: "create", Device: device, Address: address, Err: err}
 	}
-	if err := ip("address", "add", address, "dev", Interface); err != nil {
-		return err
+	if err := netlink.LinkSetUp(mv); err != nil {
+		return &Error{Op: "create", Device: device, Err: err}
 	}
-	if err := ip("link", "set", "dev", Interface, "up"); err != nil {
-		return err
+	// only routes to containers go through the device
+	routes, err := netlink.RouteList(mv, netlink.FAMILY_ALL)
+	if err != nil {
+		return &Error{Op: "create", Device: device, Err: err}
+	}
+	for _, r := range routes {
+		// neighbor discovery needs the ipv6 link-local route
+		if r.Dst != nil && (r.Dst.IP.IsLinkLocalUnicast() || r.Dst.IP.IsLinkLocalMulticast()) {
+			continue
+		}
+		if err := netlink.RouteDel(&r); err != nil && err != unix.ESRCH {
+			return &Error{Op: "create", Device: device, Address: r.Dst.String(), Err: err}
+		}
 	}
-	return ip("route", "flush", "dev", Interface)
+	return nil
 }
 
-func Add(address string) error {
-	return ip("route", "add", address, "dev", Interface, "metric", "0")
+// Add routes the address through the macvlan device.
+// Adding an existing route is not an error.
+func Add(device, address string) error {
+	r, err := newRoute(device, address)
+	if err != nil {
+		return &Error{Op: "add", Device: device, Address: address, Err: err}
+	}
+	if err := netlink.RouteReplace(r); err != nil {
+		return &Error{Op: "add", Device: device, Address: address, Err: err}
+	}
+	return nil
 }
 
-func Remove(address string) error {
-	return ip("route", "del", address, "dev", Interface)
+// Remove removes the route for the address.
+// Removing a route or device that does not exist is not an error.
+func Remove(device, address string) error {
+	r, err := newRoute(device, address)
+	if err != nil {
+		if err == ErrDeviceNotFound {
+			return nil
+		}
+		return &Error{Op: "remove", Device: device, Address: address, Err: err}
+	}
+	if err := netlink.RouteDel(r); err != nil && err != unix.ESRCH {
+		return &Error{Op: "remove", Device: device, Address: address, Err: err}
+	}
+	return nil
 }
 
-func ip(args ...string) error {
-	out, err := exec.Command("ip", args...).CombinedOutput()
+// List returns the addresses routed through the macvlan device
+func List(device string) ([]string, error) {
+	l, err := link(device)
 	if err != nil {
-		return errors.Wrap(err, string(out))
+		return nil, &Error{Op: "list", Device: device, Err: err}
 	}
-	return nil
+	routes, err := netlink.RouteList(l, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, &Error{Op: "list", Device: device, Err: err}
+	}
+	var addresses []string
+	for _, r := range routes {
+		if r.Dst == nil {
+			continue
+		}
+		if ones, bits := r.Dst.Mask.Size(); ones != bits {
+			continue
+		}
+		addresses = append(addresses, r.Dst.IP.String())
+	}
+	return addresses, nil
+}
+
+func newRoute(device, address string) (*netlink.Route, error) {
+	l, err := link(device)
+	if err != nil {
+		return nil, err
+	}
+	_, dst, err := net.ParseCIDR(withMask(address))
+	if err != nil {
+		return nil, err
+	}
+	return &netlink.Route{
+		LinkIndex: l.Attrs().Index,
+		Dst:       dst,
+		Scope:     netlink.SCOPE_LINK,
+		Priority:  0,
+	}, nil
+}
+
+func link(name string) (netlink.Link, error) {
+	l, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil, ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// withMask returns the address as a host address if it has no mask
+func withMask(address string) string {
+	if strings.Contains(address, "/") {
+		return address
+	}
+	if strings.Contains(address, ":") {
+		return address + "/128"
+	}
+	return address + "/32"
 }
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// NewIPAM returns an ipam that reserves addresses in the cluster's store for the node.
+// The pool must be connected to the master store so that reservations are conflict free.
+func NewIPAM(pool *redis.Pool, node string) *IPAM {
+	return &IPAM{
+		store: New(pool),
+		node:  node,
+	}
+}
+
+// IPAM reserves addresses with SETNX on a key per address so only one
+// container on one node in the cluster can hold an address
+type IPAM struct {
+	store *Store
+	node  string
+}
+
+// Reserve reserves the ip for the container or an address from the subnet if ip is empty.
+// A container keeps its address while it is reserved on the node.
+func (i *IPAM) Reserve(network, subnet, id, ip string, exclude ...string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse subnet of network %s", network)
+	}
+	if ipnet.IP.To4() == nil {
+		return "", errors.Errorf("subnet %s of network %s is not ipv4", subnet, network)
+	}
+	current, err := redis.String(i.store.do("GET", containerKey(network, i.node, id)))
+	if err != nil && err != redis.ErrNil {
+		return "", err
+	}
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, e := range exclude {
+		excluded[strings.SplitN(e, "/", 2)[0]] = struct{}{}
+	}
+	hosts := hostRange(ipnet)
+	if ip == "" {
+		ip = current
+	}
+	if ip != "" {
+		p := net.ParseIP(ip).To4()
+		if p == nil || !ipnet.Contains(p) {
+			return "", errors.Errorf("ip %s is not in subnet %s of network %s", ip, subnet, network)
+		}
+		if _, ok := excluded[p.String()]; ok || !hosts.contains(p) {
+			return "", errors.Errorf("ip %s is reserved on network %s", ip, network)
+		}
+		ok, holder, err := i.claim(network, p.String(), i.owner(id))
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", errors.Errorf("ip %s on network %s is reserved by %s", ip, network, holder)
+		}
+		ip = p.String()
+	} else {
+		if ip, err = i.next(network, id, hosts, excluded); err != nil {
+			return "", err
+		}
+	}
+	// the container was moved to a new address
+	if current != "" && current != ip {
+		if err := i.release(network, current, i.owner(id)); err != nil {
+			return "", err
+		}
+	}
+	if _, err := i.store.do("SET", containerKey(network, i.node, id), ip); err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+// moveScript hands the address of the container on the node in KEYS[1] to the container
+// key in KEYS[2] if it is still held by ARGV[2], ARGV[1] is the prefix of the address keys
+var moveScript = redis.NewScript(2, `
+local ip = redis.call("GET", KEYS[1])
+if not ip then
+	return ""
+end
+local key = ARGV[1] .. ip
+if redis.call("GET", key) ~= ARGV[2] then
+	return redis.error_reply("ip " .. ip .. " is not held by " .. ARGV[2])
+end
+redis.call("SET", key, ARGV[3])
+redis.call("SET", KEYS[2], ip)
+redis.call("DEL", KEYS[1])
+return ip
+`)
+
+// releaseScript deletes the address key in KEYS[1] if it is held by ARGV[1]
+var releaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Move takes over the address the container holds on the node it is migrated from
+func (i *IPAM) Move(network, from, id string) (string, error) {
+	if from == i.node {
+		return "", nil
+	}
+	ip, err := redis.String(i.store.eval(moveScript,
+		containerKey(network, from, id),
+		containerKey(network, i.node, id),
+		addressKey(network, ""),
+		fmt.Sprintf("%s/%s", from, id),
+		i.owner(id),
+	))
+	if err != nil && err != redis.ErrNil {
+		return "", errors.Wrapf(err, "move address on network %s", network)
+	}
+	return ip, nil
+}
+
+// Release releases the container's address on the network if it is held by the node
+func (i *IPAM) Release(network, id string) error {
+	ip, err := redis.String(i.store.do("GET", containerKey(network, i.node, id)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil
+		}
+		return err
+	}
+	if err := i.release(network, ip, i.owner(id)); err != nil {
+		return err
+	}
+	_, err = i.store.do("DEL", containerKey(network, i.node, id))
+	return err
+}
+
+// next claims the first free address of the range
+func (i *IPAM) next(network, id string, hosts ipRange, excluded map[string]struct{}) (string, error) {
+	for n := hosts.first; n <= hosts.last; n++ {
+		ip := toIP(n).String()
+		if _, ok := excluded[ip]; ok {
+			continue
+		}
+		ok, _, err := i.claim(network, ip, i.owner(id))
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return ip, nil
+		}
+	}
+	return "", errors.Errorf("no free addresses on network %s", network)
+}
+
+// claim reserves the address for the owner returning the holder when it is held by another
+func (i *IPAM) claim(network, ip, owner string) (bool, string, error) {
+	key := addressKey(network, ip)
+	ok, err := redis.Bool(i.store.do("SETNX", key, owner))
+	if err != nil {
+		return false, "", err
+	}
+	if ok {
+		return true, "", nil
+	}
+	holder, err := redis.String(i.store.do("GET", key))
+	if err != nil && err != redis.ErrNil {
+		return false, "", err
+	}
+	if holder != owner {
+		return false, holder, nil
+	}
+	return true, "", nil
+}
+
+// release removes the address if it is held by the owner, the container may have been
+// moved to another node which now holds the address
+func (i *IPAM) release(network, ip, owner string) error {
+	_, err := i.store.eval(releaseScript, addressKey(network, ip), owner)
+	return err
+}
+
+func (i *IPAM) owner(id string) string {
+	return fmt.Sprintf("%s/%s", i.node, id)
+}
+
+func addressKey(network, ip string) string {
+	return fmt.Sprintf("io.boss.ipam.%s.address.%s", network, ip)
+}
+
+func containerKey(network, node, id string) string {
+	return fmt.Sprintf("io.boss.ipam.%s.container.%s.%s", network, node, id)
+}
+
+// ipRange is the range of addresses that can be handed out in a subnet
+type ipRange struct {
+	first, last uint32
+}
+
+func (r ipRange) contains(ip net.IP) bool {
+	n := toUint(ip)
+	return n >= r.first && n <= r.last
+}
+
+// hostRange skips the network address, the first address that is used as the
+// gateway, and the broadcast address of the subnet
+func hostRange(ipnet *net.IPNet) ipRange {
+	ones, bits := ipnet.Mask.Size()
+	var (
+		start = toUint(ipnet.IP)
+		size  = uint32(1) << uint(bits-ones)
+	)
+	if size < 4 {
+		return ipRange{first: 1, last: 0}
+	}
+	return ipRange{
+		first: start + 2,
+		last:  start + size - 2,
+	}
+}
+
+func toUint(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func toIP(n uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
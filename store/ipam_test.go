@@ -0,0 +1,91 @@
+package store
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	lconfig "github.com/siddontang/ledisdb/config"
+	"github.com/siddontang/ledisdb/server"
+)
+
+// newLedisPool serves a ledis store from a temporary directory
+func newLedisPool(t *testing.T) (*redis.Pool, func()) {
+	dir, err := ioutil.TempDir("", "store-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	l.Close()
+
+	cfg := lconfig.NewConfigDefault()
+	cfg.Addr = address
+	cfg.DataDir = dir
+	app, err := server.NewApp(cfg)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	go app.Run()
+	pool := redis.NewPool(func() (redis.Conn, error) {
+		return redis.Dial("tcp", address)
+	}, 5)
+	return pool, func() {
+		pool.Close()
+		app.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestIPAMMove(t *testing.T) {
+	pool, cleanup := newLedisPool(t)
+	defer cleanup()
+	var (
+		a = NewIPAM(pool, "a")
+		b = NewIPAM(pool, "b")
+		c = NewIPAM(pool, "c")
+	)
+	ip, err := a.Reserve("test", "10.199.0.0/24", "redis", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Move("test", "b", "redis"); err != nil {
+		t.Fatalf("move without a reservation: %v", err)
+	}
+	moved, err := b.Move("test", "a", "redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != ip {
+		t.Fatalf("moved %q, expected %s", moved, ip)
+	}
+	// the source no longer holds the address so its release keeps the reservation of the target
+	if err := a.Release("test", "redis"); err != nil {
+		t.Fatal(err)
+	}
+	if reserved, err := b.Reserve("test", "10.199.0.0/24", "redis", ""); err != nil || reserved != ip {
+		t.Fatalf("reserve after move returned %q, %v, expected %s", reserved, err, ip)
+	}
+	if _, err := c.Reserve("test", "10.199.0.0/24", "redis", ip); err == nil {
+		t.Fatalf("%s was reserved twice", ip)
+	}
+	// an address that was taken over by another container is not moved
+	if _, err := a.Reserve("test", "10.199.0.0/24", "other", ""); err != nil {
+		t.Fatal(err)
+	}
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("SET", containerKey("test", "a", "redis"), ip); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Move("test", "a", "redis"); err == nil {
+		t.Fatal("moved an address held by another node")
+	}
+}
@@ -21,3 +21,10 @@ func (s *Store) do(action string, args ...interface{}) (interface{}, error) {
 	defer conn.Close()
 	return conn.Do(action, args...)
 }
+
+// eval runs the script which is not interleaved with other scripts
+func (s *Store) eval(script *redis.Script, args ...interface{}) (interface{}, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+	return script.Do(conn, args...)
+}
@@ -0,0 +1,137 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/cmd/ctr/commands"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStopTimeout is the time to wait for a task to exit after the stop signal
+const DefaultStopTimeout = 10 * time.Second
+
+// StopSignal returns the signal used to stop the container's task
+func StopSignal(config *v1.Container) (syscall.Signal, error) {
+	if config.StopSignal == "" {
+		return syscall.SIGTERM, nil
+	}
+	return commands.ParseSignal(config.StopSignal)
+}
+
+// StopTimeout returns the time to wait for the task to exit before it is killed
+func StopTimeout(config *v1.Container) time.Duration {
+	if config.StopTimeout <= 0 {
+		return DefaultStopTimeout
+	}
+	return time.Duration(config.StopTimeout) * time.Second
+}
+
+// MaxStopTime returns the longest a stop of the container can take
+// including all pre-stop hooks
+func MaxStopTime(config *v1.Container) time.Duration {
+	d := StopTimeout(config)
+	for _, h := range config.PreStop {
+		d += hookTimeout(config, h)
+	}
+	return d
+}
+
+// Stop runs the pre-stop hooks then signals the task with the stop signal.
+// It is killed if it has not exited after the stop timeout.
+func Stop(ctx context.Context, container containerd.Container, task containerd.Task, config *v1.Container) error {
+	PreStop(ctx, container, task, config)
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if err := Signal(ctx, task, config); err != nil {
+		return err
+	}
+	return WaitOrKill(ctx, task, wait, config)
+}
+
+// Signal sends the stop signal to the task
+func Signal(ctx context.Context, task containerd.Task, config *v1.Container) error {
+	sig, err := StopSignal(config)
+	if err != nil {
+		return err
+	}
+	return task.Kill(ctx, sig)
+}
+
+// WaitOrKill waits the stop timeout for the task to exit before killing it
+func WaitOrKill(ctx context.Context, task containerd.Task, wait <-chan containerd.ExitStatus, config *v1.Container) error {
+	wctx, cancel := context.WithTimeout(ctx, StopTimeout(config))
+	defer cancel()
+	select {
+	case <-wctx.Done():
+		if err := task.Kill(ctx, syscall.SIGKILL); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+		return nil
+	case <-wait:
+		return nil
+	}
+}
+
+// PreStop runs the container's pre-stop hooks in the task.
+// Failed hooks are logged so that the task is always stopped.
+func PreStop(ctx context.Context, container containerd.Container, task containerd.Task, config *v1.Container) {
+	for i, h := range config.PreStop {
+		if err := runHook(ctx, container, task, fmt.Sprintf("pre-stop-%d", i), h, hookTimeout(config, h)); err != nil {
+			logrus.WithError(err).WithField("id", container.ID()).Errorf("pre-stop hook %v", h.Args)
+		}
+	}
+}
+
+func runHook(ctx context.Context, container containerd.Container, task containerd.Task, id string, h *v1.Hook, timeout time.Duration) error {
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	pspec := *spec.Process
+	pspec.Args = h.Args
+	pspec.Terminal = false
+	process, err := task.Exec(ctx, id, &pspec, cio.NullIO)
+	if err != nil {
+		return err
+	}
+	defer process.Delete(ctx, containerd.WithProcessKill)
+	wait, err := process.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if err := process.Start(ctx); err != nil {
+		return err
+	}
+	wctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	select {
+	case <-wctx.Done():
+		return errors.Errorf("timeout after %s", timeout)
+	case status := <-wait:
+		code, _, err := status.Result()
+		if err != nil {
+			return err
+		}
+		if code != 0 {
+			return errors.Errorf("exited with %d", code)
+		}
+		return nil
+	}
+}
+
+func hookTimeout(config *v1.Container, h *v1.Hook) time.Duration {
+	if h.Timeout <= 0 {
+		return StopTimeout(config)
+	}
+	return time.Duration(h.Timeout) * time.Second
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,9 +14,12 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/config"
+	"github.com/crosbymichael/boss/flux"
 	"github.com/crosbymichael/boss/opts"
 	"github.com/crosbymichael/boss/system"
+	"github.com/crosbymichael/boss/systemd"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
@@ -43,7 +46,7 @@ var systemdExecStartPreCommand = cli.Command{
 	Usage: "exec-start-pre proxy for containers",
 	Action: func(clix *cli.Context) error {
 		id := clix.Args().First()
-		if err := setupApparmor(); err != nil {
+		if err := setupApparmor(id); err != nil {
 			return err
 		}
 		return cleanupPreviousTask(id)
@@ -79,7 +82,7 @@ var systemdExecStopPostCommand = cli.Command{
 			return err
 		}
 		for name := range config.Services {
-			register.EnableMaintainance(id, name, "task exited")
+			register.EnableMaintainance(id, name, v1.MaintenanceExited)
 		}
 		return err
 	},
@@ -128,32 +131,85 @@ var systemdExecStartCommand = cli.Command{
 		if err != nil {
 			return err
 		}
-		ip, err := setupNetworking(ctx, container, cfg)
+		labels, err := container.Labels(ctx)
 		if err != nil {
 			return err
 		}
-		if err := container.Update(ctx, opts.WithIP(ip), opts.WithoutRestore); err != nil {
+		failures := opts.LabelUint(labels, opts.FailureCountLabel)
+		if systemd.GaveUp(cfg.Restart, failures) {
+			logrus.WithField("id", id).Errorf("giving up after %d failures", failures)
+			os.Exit(systemd.ExitGaveUp)
+		}
+		if backoff := systemd.Backoff(cfg.Restart, failures); backoff > 0 {
+			logrus.WithField("id", id).Infof("waiting %s before restart after %d failures", backoff, failures)
+			time.Sleep(backoff)
+		}
+		ips, err := setupNetworking(ctx, container, cfg)
+		if err != nil {
+			return err
+		}
+		if err := container.Update(ctx, opts.WithIPs(ips), opts.WithoutRestore, opts.WithRestart); err != nil {
+			return err
+		}
+		// init containers have already run for a restored task
+		if desc == nil {
+			status, err := runInit(ctx, client, container, cfg)
+			if err != nil {
+				return err
+			}
+			if status != 0 {
+				if err := container.Update(ctx, opts.WithTaskExit(status, false)); err != nil {
+					logrus.WithError(err).Error("record task exit")
+				}
+				os.Exit(status)
+			}
+		}
+		if err := startSidecars(ctx, client, container, cfg); err != nil {
+			stopSidecars(ctx, client, id, cfg)
 			return err
 		}
 		task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio), opts.WithTaskRestore(desc))
 		if err != nil {
+			stopSidecars(ctx, client, id, cfg)
 			return err
 		}
-		status, err := monitorTask(ctx, client, task, cfg, register, signals, templateCh)
+		started := time.Now()
+		status, stopped, err := monitorTask(ctx, client, container, task, cfg, register, signals, templateCh)
+		// sidecars are stopped after the task so they are available while it shuts down
+		stopSidecars(ctx, client, id, cfg)
 		if err != nil {
 			return err
 		}
+		// a task that was stopped or ran longer than its longest backoff is no longer crash looping
+		reset := stopped || time.Since(started) > systemd.MaxBackoff(cfg.Restart)
+		updates := []containerd.UpdateContainerOpts{
+			opts.WithTaskExit(status, reset),
+		}
+		if cfg.Type == v1.JobType {
+			updates = append(updates, opts.WithJobRun(&v1.JobRun{
+				Started:  started,
+				Ended:    time.Now(),
+				ExitCode: int32(status),
+			}))
+		}
+		if err := container.Update(ctx, updates...); err != nil {
+			logrus.WithError(err).Error("record task exit")
+		}
 		os.Exit(status)
 		return nil
 	},
 }
 
-func monitorTask(ctx context.Context, client *containerd.Client, task containerd.Task, config *v1.Container, register v1.Register, signals chan os.Signal, templateCh <-chan error) (int, error) {
+// monitorTask returns the exit status of the task and if it exited after it was stopped
+func monitorTask(ctx context.Context, client *containerd.Client, container containerd.Container, task containerd.Task, config *v1.Container, register v1.Register, signals chan os.Signal, templateCh <-chan error) (int, bool, error) {
 	defer task.Delete(ctx, containerd.WithProcessKill)
-	started := make(chan error, 1)
+	var (
+		started  = make(chan error, 1)
+		stopping bool
+	)
 	wait, err := task.Wait(ctx)
 	if err != nil {
-		return -1, err
+		return -1, false, err
 	}
 	go func() {
 		started <- task.Start(ctx)
@@ -164,7 +220,7 @@ func monitorTask(ctx context.Context, client *containerd.Client, task containerd
 			logrus.WithError(err).Error("render template")
 		case err := <-started:
 			if err != nil {
-				return -1, err
+				return -1, false, err
 			}
 			for name := range config.Services {
 				if err := register.DisableMaintainance(task.ID(), name); err != nil {
@@ -172,27 +228,47 @@ func monitorTask(ctx context.Context, client *containerd.Client, task containerd
 				}
 			}
 		case s := <-signals:
+			// systemd stops the unit with SIGTERM
+			if s == syscall.SIGTERM || s == syscall.SIGINT {
+				if !stopping {
+					stopping = true
+					go stopTask(ctx, container, task)
+				}
+				continue
+			}
 			if err := trySendSignal(ctx, client, task, s); err != nil {
 				logrus.WithError(err).Error("signal task")
 			}
 		case exit := <-wait:
 			if exit.Error() != nil {
 				if !isUnavailable(err) {
-					return -1, err
+					return -1, false, err
 				}
 				if err := reconnect(client); err != nil {
-					return -1, err
+					return -1, false, err
 				}
 				if wait, err = task.Wait(ctx); err != nil {
-					return -1, err
+					return -1, false, err
 				}
 				continue
 			}
-			return int(exit.ExitCode()), nil
+			return int(exit.ExitCode()), stopping, nil
 		}
 	}
 }
 
+// stopTask gracefully stops the task with the latest stop settings of the container
+func stopTask(ctx context.Context, container containerd.Container, task containerd.Task) {
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		logrus.WithError(err).Error("load config for stop")
+		config = &v1.Container{}
+	}
+	if err := system.Stop(ctx, container, task, config); err != nil {
+		logrus.WithError(err).Error("stop task")
+	}
+}
+
 func trySendSignal(ctx context.Context, client *containerd.Client, task containerd.Task, s os.Signal) error {
 	for i := 0; i < 5; i++ {
 		err := task.Kill(ctx, s.(syscall.Signal))
@@ -223,33 +299,190 @@ func isUnavailable(err error) bool {
 	return errdefs.IsUnavailable(errdefs.FromGRPC(err))
 }
 
-func setupNetworking(ctx context.Context, container containerd.Container, c *v1.Container) (string, error) {
+func setupNetworking(ctx context.Context, container containerd.Container, c *v1.Container) ([]string, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	network, err := cfg.GetNetwork(c.Network)
+	network, err := cfg.ContainerNetwork(c)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	register, err := cfg.GetRegister()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	ip, err := network.Create(ctx, container)
+	ips, err := network.Create(ctx, container)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if ip != "" {
-		logrus.WithField("id", container.ID()).WithField("ip", ip).Info("setup network interface")
+	if len(ips) > 0 {
+		logrus.WithField("id", container.ID()).WithField("ips", ips).Info("setup network interface")
 		for name, srv := range c.Services {
-			logrus.WithField("id", container.ID()).WithField("ip", ip).Infof("registering %s", name)
-			if err := register.Register(container.ID(), name, ip, srv); err != nil {
-				return ip, err
+			logrus.WithField("id", container.ID()).WithField("ips", ips).Infof("registering %s", name)
+			if err := register.Register(container.ID(), name, ips, srv); err != nil {
+				return ips, err
 			}
 		}
 	}
-	return ip, nil
+	return ips, nil
+}
+
+// runInit runs the container's init containers in order, returning the exit status of the first that fails
+func runInit(ctx context.Context, client *containerd.Client, container containerd.Container, c *v1.Container) (int, error) {
+	if len(c.Init) == 0 {
+		return 0, nil
+	}
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return -1, err
+	}
+	for i, init := range c.Init {
+		id := fmt.Sprintf("%s-init-%d", container.ID(), i)
+		// remove anything left behind by a proxy that did not exit cleanly
+		if err := removeContainer(ctx, client, id); err != nil {
+			return -1, err
+		}
+		image, err := client.GetImage(ctx, init.Image)
+		if err != nil {
+			return -1, errors.Wrapf(err, "get init image %s", init.Image)
+		}
+		ic, err := client.NewContainer(ctx, id,
+			withChildSnapshot(id, c, image),
+			opts.WithInitSpec(spec, c, image, init.Args),
+			containerd.WithContainerLabels(map[string]string{
+				opts.InitLabel: container.ID(),
+			}),
+		)
+		if err != nil {
+			return -1, err
+		}
+		logrus.WithField("id", container.ID()).Infof("running init %s", init.Image)
+		status, err := runTask(ctx, ic)
+		if derr := ic.Delete(ctx, containerd.WithSnapshotCleanup); derr != nil {
+			logrus.WithError(derr).Errorf("delete init container %s", id)
+		}
+		if err != nil {
+			return -1, err
+		}
+		if status != 0 {
+			logrus.WithField("id", container.ID()).Errorf("init %s exited with %d", init.Image, status)
+			return status, nil
+		}
+	}
+	return 0, nil
+}
+
+func runTask(ctx context.Context, container containerd.Container) (int, error) {
+	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio))
+	if err != nil {
+		return -1, err
+	}
+	defer task.Delete(ctx, containerd.WithProcessKill)
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if err := task.Start(ctx); err != nil {
+		return -1, err
+	}
+	status := <-wait
+	code, _, err := status.Result()
+	if err != nil {
+		return -1, err
+	}
+	return int(code), nil
+}
+
+// startSidecars creates and starts the container's sidecars in its network namespace
+func startSidecars(ctx context.Context, client *containerd.Client, container containerd.Container, c *v1.Container) error {
+	if len(c.Sidecars) == 0 {
+		return nil
+	}
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sc := range c.Sidecars {
+		id := opts.SidecarID(container.ID(), sc.Name)
+		if err := removeContainer(ctx, client, id); err != nil {
+			return err
+		}
+		image, err := client.GetImage(ctx, sc.Image)
+		if err != nil {
+			return errors.Wrapf(err, "get sidecar image %s", sc.Image)
+		}
+		scc, err := client.NewContainer(ctx, id,
+			withChildSnapshot(id, c, image),
+			opts.WithSidecarSpec(spec, c, sc, image),
+			containerd.WithContainerLabels(map[string]string{
+				opts.SidecarLabel: container.ID(),
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		task, err := scc.NewTask(ctx, cio.NewCreator(cio.WithStdio))
+		if err != nil {
+			return err
+		}
+		if err := task.Start(ctx); err != nil {
+			return err
+		}
+		logrus.WithField("id", container.ID()).Infof("started sidecar %s", sc.Name)
+	}
+	return nil
+}
+
+// stopSidecars gracefully stops and removes all of the container's sidecars
+func stopSidecars(ctx context.Context, client *containerd.Client, id string, c *v1.Container) {
+	for _, sc := range c.Sidecars {
+		if err := stopSidecar(ctx, client, opts.SidecarID(id, sc.Name)); err != nil {
+			logrus.WithError(err).WithField("id", id).Errorf("stop sidecar %s", sc.Name)
+		}
+	}
+}
+
+func stopSidecar(ctx context.Context, client *containerd.Client, id string) error {
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if task, err := container.Task(ctx, nil); err == nil {
+		if err := system.Stop(ctx, container, task, &v1.Container{}); err != nil {
+			return err
+		}
+	}
+	return removeContainer(ctx, client, id)
+}
+
+func removeContainer(ctx context.Context, client *containerd.Client, id string) error {
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if task, err := container.Task(ctx, nil); err == nil {
+		if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil {
+			return err
+		}
+	}
+	return container.Delete(ctx, containerd.WithSnapshotCleanup)
+}
+
+// withChildSnapshot creates the snapshot of an init or sidecar container with the
+// same remap as the container's own revisions
+func withChildSnapshot(id string, c *v1.Container, image containerd.Image) containerd.NewContainerOpts {
+	if c.Userns != nil {
+		uid, gid, size := opts.UserNamespaceIDs(c.Userns)
+		return flux.WithNewRemappedSnapshot(image, uid, gid, size)
+	}
+	return containerd.WithNewSnapshot(id, image)
 }
 
 func systemdPreSetup(clix *cli.Context) error {
@@ -260,10 +493,28 @@ func systemdPreSetup(clix *cli.Context) error {
 	return nil
 }
 
-func setupApparmor() error {
-	return apparmor.WithDefaultProfile("boss")(nil, nil, nil, &specs.Spec{
+func setupApparmor(id string) error {
+	if err := apparmor.WithDefaultProfile(opts.DefaultApparmorProfile)(nil, nil, nil, &specs.Spec{
 		Process: &specs.Process{},
-	})
+	}); err != nil {
+		return err
+	}
+	// custom profiles are not persisted by the kernel across reboots
+	ctx := system.Context()
+	client, err := system.NewClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return err
+	}
+	return opts.LoadApparmorProfile(config.Security)
 }
 
 func cleanupPreviousTask(id string) error {
@@ -277,6 +528,15 @@ func cleanupPreviousTask(id string) error {
 	if err != nil {
 		return err
 	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return err
+	}
+	for _, sc := range config.Sidecars {
+		if err := removeContainer(ctx, client, opts.SidecarID(id, sc.Name)); err != nil {
+			return err
+		}
+	}
 	task, err := container.Task(ctx, nil)
 	if err != nil {
 		if errdefs.IsNotFound(err) {
@@ -0,0 +1,166 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DropInRoot is where per container unit drop-ins are written
+	DropInRoot = "/etc/systemd/system"
+	// ExitGaveUp is the exit status of the container proxy when the container
+	// has failed more times than its restart policy allows
+	ExitGaveUp = 254
+
+	defaultRestartDelay    = 5
+	defaultMaxRestartDelay = 300
+
+	stopGracePeriod = 10 * time.Second
+)
+
+const restartDropIn = `[Service]
+Restart=%s
+RestartSec=%d
+RestartPreventExitStatus=%d
+`
+
+// SetRestartPolicy writes a drop-in for the container's unit with its restart policy.
+// A nil policy removes any drop-in so the defaults of the unit are used.
+func SetRestartPolicy(ctx context.Context, id string, policy *v1.RestartPolicy) error {
+	path := dropInPath(id)
+	if policy == nil {
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		return Command(ctx, "daemon-reload")
+	}
+	restart, err := restartValue(policy.Policy)
+	if err != nil {
+		return err
+	}
+	delay, _ := restartDelays(policy)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data := fmt.Sprintf(restartDropIn, restart, delay, ExitGaveUp)
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+	return Command(ctx, "daemon-reload")
+}
+
+const stopDropIn = `[Service]
+TimeoutStopSec=%d
+`
+
+// SetStopTimeout writes a drop-in for the container's unit so that systemd waits
+// for the proxy to gracefully stop the container before killing it
+func SetStopTimeout(ctx context.Context, id string, timeout time.Duration) error {
+	path := filepath.Join(filepath.Dir(dropInPath(id)), "stop.conf")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// leave time for the proxy to kill the task after the timeout
+	data := fmt.Sprintf(stopDropIn, int64((timeout+stopGracePeriod)/time.Second))
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+	return Command(ctx, "daemon-reload")
+}
+
+// RemoveDropIns removes all drop-ins for the container's unit
+func RemoveDropIns(id string) error {
+	return os.RemoveAll(filepath.Dir(dropInPath(id)))
+}
+
+// Backoff returns the additional time to wait before starting a container that has
+// failed, doubling the policy's delay after each consecutive failure.
+// The unit already waits for the initial delay before restarting.
+func Backoff(policy *v1.RestartPolicy, failures uint64) time.Duration {
+	if policy == nil || failures < 2 {
+		return 0
+	}
+	delay, max := restartDelays(policy)
+	total := delay
+	for i := uint64(1); i < failures && total < max; i++ {
+		total *= 2
+	}
+	if total > max {
+		total = max
+	}
+	return time.Duration(total-delay) * time.Second
+}
+
+// MaxBackoff returns the longest delay of the policy between restarts
+func MaxBackoff(policy *v1.RestartPolicy) time.Duration {
+	_, max := restartDelays(policy)
+	return time.Duration(max) * time.Second
+}
+
+// GaveUp returns true when the container has failed more times than its policy allows
+func GaveUp(policy *v1.RestartPolicy, failures uint64) bool {
+	return policy != nil && policy.MaxRetries > 0 && failures > uint64(policy.MaxRetries)
+}
+
+func restartDelays(policy *v1.RestartPolicy) (delay, max int64) {
+	if policy != nil {
+		delay, max = policy.Delay, policy.MaxDelay
+	}
+	if delay <= 0 {
+		delay = defaultRestartDelay
+	}
+	if max <= 0 {
+		max = defaultMaxRestartDelay
+	}
+	if max < delay {
+		max = delay
+	}
+	return delay, max
+}
+
+// ValidateRestartPolicy returns an error if the policy cannot be written as a drop-in
+func ValidateRestartPolicy(policy *v1.RestartPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Delay < 0 || policy.MaxDelay < 0 {
+		return errors.New("restart delays cannot be negative")
+	}
+	_, err := restartValue(policy.Policy)
+	return err
+}
+
+func restartValue(policy string) (string, error) {
+	switch policy {
+	case "", "always":
+		return "always", nil
+	case "on-failure":
+		return "on-failure", nil
+	case "never":
+		return "no", nil
+	}
+	return "", errors.Errorf("invalid restart policy %q", policy)
+}
+
+func dropInPath(id string) string {
+	return filepath.Join(DropInRoot, serviceName(id)+".d", "restart.conf")
+}
+
+func writeFile(path, data string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(data)
+	f.Close()
+	return err
+}
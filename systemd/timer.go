@@ -0,0 +1,191 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const timer = `[Unit]
+Description=Boss job schedule for %s
+
+[Timer]
+%s
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`
+
+// InstallTimer writes and starts a timer that runs the container's unit on the cron schedule
+func InstallTimer(ctx context.Context, id, schedule string) error {
+	calendars, err := OnCalendar(schedule)
+	if err != nil {
+		return err
+	}
+	var events []string
+	for _, c := range calendars {
+		events = append(events, "OnCalendar="+c)
+	}
+	if err := writeFile(timerPath(id), fmt.Sprintf(timer, id, strings.Join(events, "\n"))); err != nil {
+		return err
+	}
+	if err := Command(ctx, "daemon-reload"); err != nil {
+		return err
+	}
+	if err := Command(ctx, "enable", timerName(id)); err != nil {
+		return err
+	}
+	return Command(ctx, "restart", timerName(id))
+}
+
+// RemoveTimer stops and removes the container's timer if it exists
+func RemoveTimer(ctx context.Context, id string) error {
+	path := timerPath(id)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := Command(ctx, "disable", "--now", timerName(id)); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	return Command(ctx, "daemon-reload")
+}
+
+// a timer activates the service of the same name
+func timerName(id string) string {
+	return fmt.Sprintf("boss-v%d@%s.timer", Version, id)
+}
+
+func timerPath(id string) string {
+	return filepath.Join(Root, timerName(id))
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "yearly",
+	"@annually": "yearly",
+	"@monthly":  "monthly",
+	"@weekly":   "weekly",
+	"@daily":    "daily",
+	"@midnight": "daily",
+	"@hourly":   "hourly",
+}
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// OnCalendar converts a 5 field cron expression into systemd calendar events.
+// systemd requires both the day of month and day of week to match, so when cron
+// restricts both they are returned as separate events which run the job when either matches.
+func OnCalendar(cron string) ([]string, error) {
+	if m, ok := cronMacros[cron]; ok {
+		return []string{m}, nil
+	}
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid cron schedule %q: expected 5 fields", cron)
+	}
+	var (
+		names  = []string{"minute", "hour", "day of month", "month", "day of week"}
+		bounds = [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+		parsed = make([]string, 5)
+	)
+	for i, f := range fields {
+		values, err := cronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cron %s %q", names[i], f)
+		}
+		if values == nil {
+			parsed[i] = "*"
+			continue
+		}
+		var s []string
+		for _, v := range values {
+			if i == 4 {
+				s = append(s, weekdays[v%7])
+				continue
+			}
+			s = append(s, strconv.Itoa(v))
+		}
+		parsed[i] = strings.Join(unique(s), ",")
+	}
+	var (
+		day  = fmt.Sprintf("*-%s-%s %s:%s:00", parsed[3], parsed[2], parsed[1], parsed[0])
+		week = fmt.Sprintf("%s *-%s-* %s:%s:00", parsed[4], parsed[3], parsed[1], parsed[0])
+	)
+	switch {
+	case parsed[4] == "*":
+		return []string{day}, nil
+	case parsed[2] == "*":
+		return []string{week}, nil
+	}
+	return []string{day, week}, nil
+}
+
+// cronField returns the values matched by the field or nil if it matches everything
+func cronField(f string, min, max int) ([]int, error) {
+	if f == "*" {
+		return nil, nil
+	}
+	var values []int
+	for _, part := range strings.Split(f, ",") {
+		var (
+			step  = 1
+			start = min
+			end   = max
+			err   error
+		)
+		if i := strings.Index(part, "/"); i != -1 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return nil, errors.Errorf("invalid step %q", part[i+1:])
+			}
+			part = part[:i]
+		}
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			r := strings.SplitN(part, "-", 2)
+			if start, err = strconv.Atoi(r[0]); err != nil {
+				return nil, err
+			}
+			if end, err = strconv.Atoi(r[1]); err != nil {
+				return nil, err
+			}
+		default:
+			if start, err = strconv.Atoi(part); err != nil {
+				return nil, err
+			}
+			if step == 1 {
+				end = start
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, errors.Errorf("%d-%d out of range %d-%d", start, end, min, max)
+		}
+		for v := start; v <= end; v += step {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func unique(s []string) (o []string) {
+	seen := make(map[string]struct{})
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		o = append(o, v)
+	}
+	return o
+}
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"github.com/BurntSushi/toml"
+	"fmt"
+
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/cmd"
 	"github.com/urfave/cli"
@@ -15,8 +16,12 @@ var updateCommand = cli.Command{
 			path = clix.Args().First()
 			ctx  = Context()
 		)
-		var newConfig cmd.Container
-		if _, err := toml.DecodeFile(path, &newConfig); err != nil {
+		newConfig, err := cmd.Load(path)
+		if err != nil {
+			return err
+		}
+		config, err := newConfig.Proto()
+		if err != nil {
 			return err
 		}
 		agent, err := Agent(clix)
@@ -24,9 +29,13 @@ var updateCommand = cli.Command{
 			return err
 		}
 		defer agent.Close()
-		_, err = agent.Update(ctx, &v1.UpdateRequest{
-			Container: newConfig.Proto(),
+		resp, err := agent.Update(ctx, &v1.UpdateRequest{
+			Container: config,
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s\n", config.ID, resp.Action)
+		return nil
 	},
 }
@@ -7,12 +7,37 @@ import (
 
 var ErrIPAddressNotFound = errors.New("box: ip address for interface not found")
 
+// GetIP returns the ipv4 address of the interface or its ipv6 address
+// when the interface only has ipv6
 func GetIP(name string) (string, error) {
-	i, err := net.InterfaceByName(name)
+	ips, err := GetIPs(name)
 	if err != nil {
 		return "", err
 	}
-	return getIPf(i, ipv4)
+	return ips[0], nil
+}
+
+// GetIPs returns the ipv4 and global ipv6 addresses of the interface with ipv4 first
+func GetIPs(name string) ([]string, error) {
+	i, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, f := range []func(n *net.IPNet) string{ipv4, ipv6} {
+		ip, err := getIPf(i, f)
+		if err != nil {
+			if err == ErrIPAddressNotFound {
+				continue
+			}
+			return nil, err
+		}
+		ips = append(ips, ip)
+	}
+	if len(ips) == 0 {
+		return nil, ErrIPAddressNotFound
+	}
+	return ips, nil
 }
 
 func getIPf(i *net.Interface, ipfunc func(n *net.IPNet) string) (string, error) {
@@ -40,3 +65,10 @@ func ipv4(n *net.IPNet) string {
 	}
 	return n.IP.To4().String()
 }
+
+func ipv6(n *net.IPNet) string {
+	if n.IP.To4() != nil || !n.IP.IsGlobalUnicast() {
+		return ""
+	}
+	return n.IP.String()
+}